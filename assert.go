@@ -2,6 +2,8 @@ package assert
 
 import (
 	"fmt"
+
+	"github.com/PlayerR9/go-verify/message"
 )
 
 // Cond checks a condition and if it is not true, panics with ErrAssertFail.
@@ -13,12 +15,16 @@ import (
 // Panics:
 //   - ErrAssertFail: If the condition is not true.
 func Cond(cond bool, msg string) {
+	if !enabled {
+		return
+	}
+
 	if cond {
 		return
 	}
 
-	err := NewErrAssertFail(msg)
-	panic(err)
+	err := newErrAssertFail(msg)
+	fail(err)
 }
 
 // Condf checks a condition and if it is not true, panics with ErrAssertFail.
@@ -31,12 +37,16 @@ func Cond(cond bool, msg string) {
 // Panics:
 //   - ErrAssertFail: If the condition is not true.
 func Condf(cond bool, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
 	if cond {
 		return
 	}
 
-	err := NewErrAssertFail(fmt.Sprintf(format, args...))
-	panic(err)
+	err := newErrAssertFail(fmt.Sprintf(format, args...))
+	fail(err)
 }
 
 // Err panics with ErrAssertFail if the given inner error is not nil. The
@@ -45,6 +55,9 @@ func Condf(cond bool, format string, args ...any) {
 // format string is empty, the error message will be "func() = " followed by the
 // error message of the inner error.
 //
+// The inner error is kept reachable through errors.Unwrap, so errors.Is and
+// errors.As can find it on the recovered ErrAssertFail.
+//
 // Parameters:
 //   - inner: The inner error to check.
 //   - format: The format string for the error message.
@@ -53,6 +66,10 @@ func Condf(cond bool, format string, args ...any) {
 // Panics:
 //   - ErrAssertFail: If the inner error is not nil.
 func Err(inner error, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
 	if inner == nil {
 		return
 	}
@@ -62,8 +79,10 @@ func Err(inner error, format string, args ...any) {
 		msg = "func()"
 	}
 
-	err := NewErrAssertFail(msg + " = " + inner.Error())
-	panic(err)
+	err := newErrAssertFail(message.Format(message.IsErr, "name", msg, "err", inner.Error()))
+	err.Inner = inner
+
+	fail(err)
 }
 
 // True checks whether a boolean condition is true. If not, it panics with ErrAssertFail
@@ -78,6 +97,10 @@ func Err(inner error, format string, args ...any) {
 // Panics:
 //   - ErrAssertFail: If the condition is false.
 func True(ok bool, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
 	if ok {
 		return
 	}
@@ -87,8 +110,8 @@ func True(ok bool, format string, args ...any) {
 		msg = "func()"
 	}
 
-	err := NewErrAssertFail(msg + " = false")
-	panic(err)
+	err := newErrAssertFail(message.Format(message.IsFalse, "name", msg))
+	fail(err)
 }
 
 // False checks whether a boolean condition is false. If not, it panics with ErrAssertFail
@@ -103,6 +126,10 @@ func True(ok bool, format string, args ...any) {
 // Panics:
 //   - ErrAssertFail: If the condition is true.
 func False(ok bool, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
 	if !ok {
 		return
 	}
@@ -112,8 +139,8 @@ func False(ok bool, format string, args ...any) {
 		msg = "func()"
 	}
 
-	err := NewErrAssertFail(msg + " = true")
-	panic(err)
+	err := newErrAssertFail(message.Format(message.IsTrue, "name", msg))
+	fail(err)
 }
 
 // NotZero asserts whether the variable is not its zero value. If not, it
@@ -129,6 +156,10 @@ func False(ok bool, format string, args ...any) {
 //
 //	NotZero[int](v, "v") // Panics: v is zero
 func NotZero[E comparable](v E, name string) {
+	if !enabled {
+		return
+	}
+
 	if v != *new(E) {
 		return
 	}
@@ -137,8 +168,10 @@ func NotZero[E comparable](v E, name string) {
 		name = "variable"
 	}
 
-	err := NewErrAssertFail(name + " is zero")
-	panic(err)
+	err := newErrAssertFail(message.Format(message.IsZero, "name", name))
+	err.Kind = KindZero
+
+	fail(err)
 }
 
 // Type asserts whether the variable is of type E. If not, it panics with an
@@ -154,14 +187,22 @@ func NotZero[E comparable](v E, name string) {
 //	v := "foo"
 //	Type[int](v, "v", false) // Panics: v = string, want int
 func Type[E any](v any, name string, allow_nil bool) {
+	if !enabled {
+		return
+	}
+
 	if name == "" {
 		name = "variable"
 	}
 
-	if v == nil && !allow_nil {
-		err := NewErrAssertFail(name + " = nil")
-		panic(err)
-	} else if v == nil {
+	if v == nil {
+		if !allow_nil {
+			err := newErrAssertFail(message.Format(message.IsNil, "name", name))
+			err.Kind = KindNil
+
+			fail(err)
+		}
+
 		return
 	}
 
@@ -170,10 +211,16 @@ func Type[E any](v any, name string, allow_nil bool) {
 		return
 	}
 
-	msg := fmt.Sprintf("%s = %T, want %T", name, v, *new(E))
+	msg := message.Format(message.TypeMismatch,
+		"name", name,
+		"got", fmt.Sprintf("%T", v),
+		"want", fmt.Sprintf("%T", *new(E)),
+	)
 
-	err := NewErrAssertFail(msg)
-	panic(err)
+	err := newErrAssertFail(msg)
+	err.Kind = KindType
+
+	fail(err)
 }
 
 // Deref asserts whether the variable is both non-nil and is of type E. If
@@ -184,13 +231,18 @@ func Type[E any](v any, name string, allow_nil bool) {
 //   - name: The name of the variable. If empty, the name "variable" is used.
 //
 // Returns:
-//   - E: The dereferenced variable.
+//   - E: The dereferenced variable. The zero value if v is nil and the current
+//     mode does not panic.
 //
 // Example:
 //
 //	var v *int
 //	_ = Deref[string](v, "v") // Panics: v = *int, want string
 func Deref[E any](v *E, name string) E {
+	if !enabled {
+		return *v
+	}
+
 	if name == "" {
 		name = "variable"
 	}
@@ -199,10 +251,14 @@ func Deref[E any](v *E, name string) E {
 		return *v
 	}
 
-	msg := fmt.Sprintf("%s = nil, want %T", name, *new(E))
+	msg := message.Format(message.IsNilWant, "name", name, "want", fmt.Sprintf("%T", *new(E)))
+
+	err := newErrAssertFail(msg)
+	err.Kind = KindNil
 
-	err := NewErrAssertFail(msg)
-	panic(err)
+	fail(err)
+
+	return *new(E)
 }
 
 // Conv asserts whether the variable is of type E. If not, it panics with an
@@ -213,18 +269,31 @@ func Deref[E any](v *E, name string) E {
 //   - v: The variable to assert.
 //   - name: The name of the variable. If empty, the name "variable" is used.
 //
+// Returns:
+//   - E: The converted variable. The zero value if the assertion fails and the
+//     current mode does not panic.
+//
 // Example:
 //
 //	v := "foo"
 //	res := Conv[int](v, "v") // Panics: v = string, want int
 func Conv[E any](v any, name string) E {
+	if !enabled {
+		val, _ := v.(E)
+		return val
+	}
+
 	if name == "" {
 		name = "variable"
 	}
 
 	if v == nil {
-		err := NewErrAssertFail(name + " = nil")
-		panic(err)
+		err := newErrAssertFail(message.Format(message.IsNil, "name", name))
+		err.Kind = KindNil
+
+		fail(err)
+
+		return *new(E)
 	}
 
 	val, ok := v.(E)
@@ -232,10 +301,18 @@ func Conv[E any](v any, name string) E {
 		return val
 	}
 
-	msg := fmt.Sprintf("%s = %T, want %T", name, v, *new(E))
+	msg := message.Format(message.TypeMismatch,
+		"name", name,
+		"got", fmt.Sprintf("%T", v),
+		"want", fmt.Sprintf("%T", *new(E)),
+	)
+
+	err := newErrAssertFail(msg)
+	err.Kind = KindType
 
-	err := NewErrAssertFail(msg)
-	panic(err)
+	fail(err)
+
+	return *new(E)
 }
 
 // NotNil asserts whether the variable is not nil. If it is nil, it panics
@@ -253,10 +330,20 @@ func Conv[E any](v any, name string) E {
 //	var v *int
 //	NotNil[int](v, "v") // Panics: v = nil
 func NotNil[E any](v *E, name string) {
+	if !enabled {
+		return
+	}
+
 	if v != nil {
 		return
 	}
 
-	err := NewErrAssertFail(name + " = nil")
-	panic(err)
+	if name == "" {
+		name = "variable"
+	}
+
+	err := newErrAssertFail(message.Format(message.IsNil, "name", name))
+	err.Kind = KindNil
+
+	fail(err)
 }
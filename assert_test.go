@@ -1,8 +1,12 @@
+//go:build !verify_noassert
+
 package assert
 
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"testing"
 
 	test "github.com/PlayerR9/go-verify/test"
@@ -134,6 +138,32 @@ func TestErr(t *testing.T) {
 	_ = tests.Run(t)
 }
 
+// TestErrUnwrap tests that Err keeps the inner error reachable.
+func TestErrUnwrap(t *testing.T) {
+	_, inner := os.Open("does-not-exist")
+
+	caught := test.Try(func() {
+		Err(inner, "os.Open(%q)", "does-not-exist")
+	})
+
+	err := test.CHECK.ErrorMessage("", NewErrAssertFail(`os.Open("does-not-exist") = `+inner.Error()).Error(), caught)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ok := errors.Is(caught, os.ErrNotExist)
+	if !ok {
+		t.Errorf("want errors.Is(err, os.ErrNotExist) to be true, got false")
+	}
+
+	var path_err *fs.PathError
+
+	ok = errors.As(caught, &path_err)
+	if !ok {
+		t.Errorf("want errors.As to find a *fs.PathError")
+	}
+}
+
 // TestTrue tests the True function.
 func TestTrue(t *testing.T) {
 	type args struct {
@@ -1,14 +1,105 @@
 package assert
 
+import (
+	"fmt"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
 // FoundBug panics with the given message, indicating that a bug has been found.
+// Unlike the other assertions, it always panics, regardless of the mode, the
+// handler and the "verify_noassert" build tag.
 //
-// The message should be a single line of text explaining the bug.
+// The message should be a single line of text explaining the bug. The
+// key/value pairs are kept in the error to help diagnose the bug. A key
+// without a value is given the value "<missing>".
 //
 // Parameters:
 //   - msg: The message to be displayed when panicking.
+//   - kv: The context of the bug, as alternating keys and values.
+//
+// Panics:
+//   - *ErrBug: Always. It wraps an ErrAssertFail of kind KindBug.
+//
+// Example:
+//
+//	FoundBug("unknown token", "kind", tk.Kind, "pos", tk.Pos)
+//	// Panics: [bug] unknown token (kind=7, pos=12)
+func FoundBug(msg string, kv ...any) {
+	err := NewErrBug(msg, kv...)
+
+	fail_count.Add(1)
+
+	panic(err)
+}
+
+// Unreachable panics to signal that the code was reached although it should
+// not have been, such as the default case of an exhaustive switch. Like
+// FoundBug, it always panics.
+//
+// Parameters:
+//   - format: The format string of the error message. If empty, "unreachable
+//     code was reached" is used.
+//   - args: The arguments to use with the format string.
 //
-// Panics with:
-//   - [bug] <msg>
-func FoundBug(msg string) {
-	panic("[bug] " + msg)
+// Panics:
+//   - *ErrAssertFail: Always. The failure is of kind KindUnreachable.
+//
+// Example:
+//
+//	switch m {
+//	case ModePanic, ModeLog, ModeCount:
+//		// ...
+//	default:
+//		Unreachable("mode = %v", m)
+//	}
+func Unreachable(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if msg == "" {
+		msg = message.Format(message.Unreachable)
+	}
+
+	err := newErrAssertFail(msg)
+	err.Kind = KindUnreachable
+
+	fail_count.Add(1)
+
+	panic(err)
+}
+
+// makeFields pairs the given keys and values.
+//
+// Parameters:
+//   - kv: The alternating keys and values.
+//
+// Returns:
+//   - []Field: The fields. Nil if kv is empty.
+func makeFields(kv []any) []Field {
+	if len(kv) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, (len(kv)+1)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+
+		var value any
+
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		} else {
+			value = "<missing>"
+		}
+
+		fields = append(fields, Field{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	return fields
 }
@@ -0,0 +1,171 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestFoundBug tests the FoundBug function.
+func TestFoundBug(t *testing.T) {
+	type args struct {
+		msg      string
+		kv       []any
+		want_err string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(func() {
+				FoundBug(args.msg, args.kv...)
+			})
+
+			err := test.CHECK.ErrorMessage("", args.want_err, caught)
+			if err != nil {
+				return err
+			}
+
+			var bug *ErrBug
+
+			ok := errors.As(caught, &bug)
+			if !ok {
+				err := test.NewErrTest("error", "*ErrBug", "something else")
+				return err
+			}
+
+			kind, ok := KindOf(caught)
+			if !ok || kind != KindBug {
+				err := test.NewErrTest("kind", KindBug.String(), kind.String())
+				return err
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("without context", args{
+		msg:      "foo",
+		want_err: "[bug] foo",
+	})
+
+	_ = tests.Add("with context", args{
+		msg:      "unknown token",
+		kv:       []any{"kind", 7, "pos", 12},
+		want_err: "[bug] unknown token (kind=7, pos=12)",
+	})
+
+	_ = tests.Add("missing value", args{
+		msg:      "foo",
+		kv:       []any{"bar"},
+		want_err: "[bug] foo (bar=<missing>)",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestErrBugValue tests the ErrBug.Value method.
+func TestErrBugValue(t *testing.T) {
+	var bug *ErrBug
+
+	_ = errors.As(NewErrBug("foo", "pos", 12), &bug)
+
+	v, ok := bug.Value("pos")
+	if !ok || v != 12 {
+		t.Errorf("want pos to be 12, got %v", v)
+	}
+
+	_, ok = bug.Value("kind")
+	if ok {
+		t.Errorf("want kind to be missing, got present")
+	}
+}
+
+// TestUnreachable tests the Unreachable function.
+func TestUnreachable(t *testing.T) {
+	prev := SetMode(ModeCount)
+	defer SetMode(prev)
+
+	caught := test.Try(func() {
+		Unreachable("")
+	})
+
+	err := test.CHECK.ErrorMessage("", NewErrAssertFail("unreachable code was reached").Error(), caught)
+	if err != nil {
+		t.Error(err)
+	}
+
+	kind, _ := KindOf(caught)
+	if kind != KindUnreachable {
+		t.Errorf("want kind to be %s, got %s", KindUnreachable, kind)
+	}
+}
+
+// TestKind tests that the assertions report the kind of their failures.
+func TestKind(t *testing.T) {
+	type args struct {
+		fn   func()
+		want Kind
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			kind, ok := KindOf(caught)
+			if !ok {
+				err := test.NewErrTest("error", "an ErrAssertFail", "something else")
+				return err
+			}
+
+			err := test.CHECK.String("kind", args.want.String(), kind.String())
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Cond", args{
+		fn:   func() { Cond(false, "foo") },
+		want: KindCondition,
+	})
+
+	_ = tests.Add("NotNil", args{
+		fn:   func() { NotNil[int](nil, "v") },
+		want: KindNil,
+	})
+
+	_ = tests.Add("NotZero", args{
+		fn:   func() { NotZero(0, "v") },
+		want: KindZero,
+	})
+
+	_ = tests.Add("Type", args{
+		fn:   func() { Type[int]("foo", "v", false) },
+		want: KindType,
+	})
+
+	_ = tests.Add("Type nil", args{
+		fn:   func() { Type[int](nil, "v", false) },
+		want: KindNil,
+	})
+
+	_ = tests.Add("soft NotZero", args{
+		fn: func() {
+			Soft(func(s *Scope) {
+				s.NotZero("", "v")
+			})
+		},
+		want: KindZero,
+	})
+
+	_ = tests.Run(t)
+}
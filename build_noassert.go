@@ -0,0 +1,8 @@
+//go:build verify_noassert
+
+package assert
+
+// enabled is whether assertions are checked. It is false when the package is
+// built with the "verify_noassert" build tag, in which case every assertion
+// compiles down to a no-op.
+const enabled bool = false
@@ -0,0 +1,821 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// KindSentinel is the kind of the error variables created with
+	// errors.New or fmt.Errorf.
+	KindSentinel string = "sentinel"
+
+	// KindCoded is the kind of the error variables created with the
+	// constructors of the coded package.
+	KindCoded string = "coded"
+
+	// KindCode is the kind of the codes declared with the coded package.
+	KindCode string = "code"
+
+	// KindType is the kind of the error types.
+	KindType string = "type"
+)
+
+// Entry is an error of the catalog.
+type Entry struct {
+	// Package is the import path of the package that defines the error.
+	Package string `json:"package"`
+
+	// Name is the name of the error.
+	Name string `json:"name"`
+
+	// Kind is the kind of the error. One of KindSentinel, KindCoded, KindCode
+	// and KindType.
+	Kind string `json:"kind"`
+
+	// Code is the code of the error, if any. A code declared in the same
+	// package is given by its value; any other by its source, such as
+	// "other.CodeBusy".
+	Code string `json:"code,omitempty"`
+
+	// Message is the literal message of the error, if any.
+	Message string `json:"message,omitempty"`
+
+	// Summary is the first paragraph of the doc comment.
+	Summary string `json:"summary,omitempty"`
+
+	// Format is the content of the "Format:" block of the doc comment.
+	Format string `json:"format,omitempty"`
+
+	// Position is the location of the definition, as "<file>:<line>".
+	Position string `json:"position"`
+}
+
+// runErrCatalog runs the errcatalog command.
+//
+// Parameters:
+//   - args: The arguments of the command.
+//   - stdout: The writer of the catalog.
+//   - stderr: The writer of the diagnostics.
+//
+// Returns:
+//   - error: An error if the catalog could not be generated.
+func runErrCatalog(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("errcatalog", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	format := flags.String("format", "markdown", "output format: markdown or json")
+	output := flags.String("o", "", "write the catalog to this file instead of the standard output")
+
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "usage: go-verify errcatalog [-format markdown|json] [-o file] [packages]")
+		_, _ = fmt.Fprintln(stderr)
+		_, _ = fmt.Fprintln(stderr, "Packages are directories; a trailing /... includes every subdirectory. Defaults to ./...")
+		_, _ = fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	var write func(w io.Writer, entries []Entry) error
+
+	switch *format {
+	case "markdown", "md":
+		write = writeMarkdown
+	case "json":
+		write = writeJSON
+	default:
+		return fmt.Errorf("unknown format %q, want markdown or json", *format)
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	dirs, err := expandPatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	var entries []Entry
+
+	for _, dir := range dirs {
+		found, err := loadCatalog(dir)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, found...)
+	}
+
+	if *output == "" {
+		err := write(stdout, entries)
+		return err
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+
+	err = write(w, entries)
+	if err == nil {
+		err = w.Flush()
+	}
+
+	close_err := f.Close()
+
+	return errors.Join(err, close_err)
+}
+
+// expandPatterns turns package patterns into directories. A pattern that ends
+// with "/..." matches the directory and every subdirectory, except those
+// named "testdata" or starting with "." or "_".
+//
+// Parameters:
+//   - patterns: The patterns to expand.
+//
+// Returns:
+//   - []string: The directories, without duplicates.
+//   - error: An error if a directory could not be walked.
+func expandPatterns(patterns []string) ([]string, error) {
+	var dirs []string
+
+	seen := make(map[string]struct{})
+
+	add := func(dir string) {
+		dir = filepath.Clean(dir)
+
+		_, ok := seen[dir]
+		if ok {
+			return
+		}
+
+		seen[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+
+	for _, pattern := range patterns {
+		root, ok := strings.CutSuffix(pattern, "...")
+		if !ok {
+			add(pattern)
+			continue
+		}
+
+		root = strings.TrimSuffix(root, "/")
+		if root == "" {
+			root = "."
+		}
+
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !d.IsDir() {
+				return nil
+			}
+
+			name := d.Name()
+
+			if p != root && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+
+			add(p)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dirs, nil
+}
+
+// loadCatalog loads the non-test Go files of the package in the directory and
+// returns the errors it defines.
+//
+// Parameters:
+//   - dir: The directory of the package.
+//
+// Returns:
+//   - []Entry: The errors, in the order they are defined.
+//   - error: An error if the package could not be loaded.
+func loadCatalog(dir string) ([]Entry, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		var no_go *build.NoGoError
+
+		if errors.As(err, &no_go) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	import_path := importPath(dir)
+
+	fset := token.NewFileSet()
+
+	files := make([]*ast.File, 0, len(pkg.GoFiles))
+
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	c := &collector{
+		fset:    fset,
+		pkg:     import_path,
+		funcs:   make(map[string]*ast.CommentGroup),
+		entries: nil,
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if ok && fn.Recv == nil {
+				c.funcs[fn.Name.Name] = fn.Doc
+			}
+		}
+	}
+
+	for _, file := range files {
+		c.collectFile(file)
+	}
+
+	c.resolveCodes()
+
+	return c.entries, nil
+}
+
+// importPath returns the import path of the package in the directory, as
+// derived from the closest go.mod file. If there is none, the directory
+// itself is returned.
+//
+// Parameters:
+//   - dir: The directory of the package.
+//
+// Returns:
+//   - string: The import path.
+func importPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+
+	for root := abs; ; {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			mod := modulePath(data)
+			if mod == "" {
+				break
+			}
+
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				break
+			}
+
+			p := path.Join(mod, filepath.ToSlash(rel))
+			return p
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			break
+		}
+
+		root = parent
+	}
+
+	return filepath.ToSlash(dir)
+}
+
+// modulePath returns the module path declared in the content of a go.mod
+// file.
+//
+// Parameters:
+//   - data: The content of the go.mod file.
+//
+// Returns:
+//   - string: The module path. Empty if there is none.
+func modulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+
+		mod, err := strconv.Unquote(fields[1])
+		if err != nil {
+			mod = fields[1]
+		}
+
+		return mod
+	}
+
+	return ""
+}
+
+// collector collects the errors of the files of a package.
+type collector struct {
+	// fset is the file set of the files.
+	fset *token.FileSet
+
+	// pkg is the import path of the package.
+	pkg string
+
+	// funcs is the doc comment of the top-level functions, by name.
+	funcs map[string]*ast.CommentGroup
+
+	// entries is the list of errors found so far.
+	entries []Entry
+
+	// imports is the import path of the imported packages of the current
+	// file, by local name.
+	imports map[string]string
+}
+
+// collectFile collects the errors defined in a file.
+//
+// Parameters:
+//   - file: The file.
+func (c *collector) collectFile(file *ast.File) {
+	c.imports = make(map[string]string, len(file.Imports))
+
+	for _, spec := range file.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		var name string
+
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else {
+			name = path.Base(p)
+		}
+
+		c.imports[name] = p
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			switch spec := spec.(type) {
+			case *ast.ValueSpec:
+				doc := spec.Doc
+				if doc == nil && !gen.Lparen.IsValid() {
+					doc = gen.Doc
+				}
+
+				c.collectValue(spec, doc)
+			case *ast.TypeSpec:
+				doc := spec.Doc
+				if doc == nil && !gen.Lparen.IsValid() {
+					doc = gen.Doc
+				}
+
+				c.collectType(spec, doc)
+			}
+		}
+	}
+}
+
+// collectValue collects the errors declared by a var or const spec.
+//
+// Parameters:
+//   - spec: The spec.
+//   - doc: The doc comment of the spec, if any.
+func (c *collector) collectValue(spec *ast.ValueSpec, doc *ast.CommentGroup) {
+	for i, name := range spec.Names {
+		if i >= len(spec.Values) {
+			break
+		}
+
+		entry := Entry{
+			Package:  c.pkg,
+			Name:     name.Name,
+			Summary:  summary(doc),
+			Format:   formatBlock(doc),
+			Position: c.position(name.Pos()),
+		}
+
+		switch value := spec.Values[i].(type) {
+		case *ast.CallExpr:
+			ok := c.classifyCall(&entry, value)
+			if !ok {
+				continue
+			}
+		case *ast.BasicLit:
+			if !c.isCodedSelector(spec.Type, "Code") {
+				continue
+			}
+
+			entry.Kind = KindCode
+			entry.Code = literal(value)
+		default:
+			continue
+		}
+
+		c.entries = append(c.entries, entry)
+	}
+}
+
+// classifyCall fills the kind, code and message of an entry whose value is
+// the given call.
+//
+// Parameters:
+//   - entry: The entry to fill.
+//   - call: The call that creates the error.
+//
+// Returns:
+//   - bool: True if the call creates an error, false otherwise.
+func (c *collector) classifyCall(entry *Entry, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	p := c.imports[ident.Name]
+
+	arg := func(i int) ast.Expr {
+		if i >= len(call.Args) {
+			return nil
+		}
+
+		return call.Args[i]
+	}
+
+	switch {
+	case p == "errors" && sel.Sel.Name == "New", p == "fmt" && sel.Sel.Name == "Errorf":
+		entry.Kind = KindSentinel
+		entry.Message = literal(arg(0))
+	case isCodedPath(p):
+		switch sel.Sel.Name {
+		case "New", "Newf":
+			entry.Kind = KindCoded
+			entry.Code = codeString(arg(0))
+			entry.Message = literal(arg(1))
+		case "Wrap":
+			entry.Kind = KindCoded
+			entry.Code = codeString(arg(0))
+			entry.Message = literal(arg(2))
+		case "MustRegister":
+			entry.Kind = KindCode
+			entry.Code = codeString(arg(0))
+			entry.Message = literal(arg(1))
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// resolveCodes replaces the code of the coded errors that name a code of the
+// same package, such as "CodeNotFound", by the value of that code, such as
+// "NOT_FOUND". It is done once every file is collected since the code may be
+// declared in any file of the package.
+func (c *collector) resolveCodes() {
+	codes := make(map[string]string)
+
+	for _, entry := range c.entries {
+		if entry.Kind == KindCode && entry.Code != "" {
+			codes[entry.Name] = entry.Code
+		}
+	}
+
+	for i := range c.entries {
+		entry := &c.entries[i]
+		if entry.Kind != KindCoded {
+			continue
+		}
+
+		code, ok := codes[entry.Code]
+		if ok {
+			entry.Code = code
+		}
+	}
+}
+
+// collectType collects an error type. Only the struct types whose name
+// starts with "Err" are collected. If the type has a "NewErr..." constructor
+// whose doc comment has a "Format:" block, it is used.
+//
+// Parameters:
+//   - spec: The type spec.
+//   - doc: The doc comment of the spec, if any.
+func (c *collector) collectType(spec *ast.TypeSpec, doc *ast.CommentGroup) {
+	name := spec.Name.Name
+
+	if !strings.HasPrefix(name, "Err") || !ast.IsExported(name) {
+		return
+	}
+
+	_, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+
+	format := formatBlock(doc)
+	if format == "" {
+		format = formatBlock(c.funcs["New"+name])
+	}
+
+	c.entries = append(c.entries, Entry{
+		Package:  c.pkg,
+		Name:     name,
+		Kind:     KindType,
+		Summary:  summary(doc),
+		Format:   format,
+		Position: c.position(spec.Name.Pos()),
+	})
+}
+
+// isCodedSelector checks whether the expression refers to the given name of
+// the coded package.
+//
+// Parameters:
+//   - expr: The expression. May be nil.
+//   - name: The name.
+//
+// Returns:
+//   - bool: True if the expression is "<coded>.<name>", false otherwise.
+func (c *collector) isCodedSelector(expr ast.Expr, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	ok = isCodedPath(c.imports[ident.Name])
+	return ok
+}
+
+// position formats a position relative to the working directory.
+//
+// Parameters:
+//   - pos: The position.
+//
+// Returns:
+//   - string: The position, as "<file>:<line>".
+func (c *collector) position(pos token.Pos) string {
+	p := c.fset.Position(pos)
+
+	filename := p.Filename
+
+	wd, err := os.Getwd()
+	if err == nil {
+		rel, err := filepath.Rel(wd, filename)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			filename = rel
+		}
+	}
+
+	return filepath.ToSlash(filename) + ":" + strconv.Itoa(p.Line)
+}
+
+// isCodedPath checks whether the import path is the one of the coded
+// package.
+//
+// Parameters:
+//   - p: The import path.
+//
+// Returns:
+//   - bool: True if the import path is the coded package, false otherwise.
+func isCodedPath(p string) bool {
+	ok := p == "coded" || strings.HasSuffix(p, "/coded")
+	return ok
+}
+
+// literal returns the value of a string literal.
+//
+// Parameters:
+//   - expr: The expression. May be nil.
+//
+// Returns:
+//   - string: The unquoted value. Empty if the expression is not a string
+//     literal.
+func literal(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+
+	str, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+
+	return str
+}
+
+// codeString returns the code given by an expression: the value of a string
+// literal, or the source of any other expression, such as the name of a
+// constant.
+//
+// Parameters:
+//   - expr: The expression. May be nil.
+//
+// Returns:
+//   - string: The code.
+func codeString(expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+
+	str := literal(expr)
+	if str != "" {
+		return str
+	}
+
+	str = types.ExprString(expr)
+	return str
+}
+
+// summary returns the first paragraph of a doc comment, on a single line.
+//
+// Parameters:
+//   - doc: The doc comment. May be nil.
+//
+// Returns:
+//   - string: The first paragraph.
+func summary(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	text := strings.TrimSpace(doc.Text())
+
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+
+	str := strings.Join(strings.Fields(paragraph), " ")
+	return str
+}
+
+// formatBlock returns the content of the "Format:" block of a doc comment:
+// the indented lines that follow the "Format:" line.
+//
+// Parameters:
+//   - doc: The doc comment. May be nil.
+//
+// Returns:
+//   - string: The content of the block, with the indentation removed. Empty
+//     if there is none.
+func formatBlock(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+
+	lines := strings.Split(doc.Text(), "\n")
+
+	start := -1
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "Format:" {
+			start = i + 1
+			break
+		}
+	}
+
+	if start < 0 {
+		return ""
+	}
+
+	var block []string
+
+	for _, line := range lines[start:] {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				break
+			}
+
+			continue
+		}
+
+		if !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, " ") {
+			break
+		}
+
+		block = append(block, strings.TrimSpace(line))
+	}
+
+	str := strings.Join(block, "\n")
+	return str
+}
+
+// writeJSON writes the catalog as a JSON array.
+//
+// Parameters:
+//   - w: The writer.
+//   - entries: The errors of the catalog.
+//
+// Returns:
+//   - error: An error if the catalog could not be written.
+func writeJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	err := enc.Encode(entries)
+	return err
+}
+
+// writeMarkdown writes the catalog as a Markdown document, with one section
+// per package.
+//
+// Parameters:
+//   - w: The writer.
+//   - entries: The errors of the catalog.
+//
+// Returns:
+//   - error: An error if the catalog could not be written.
+func writeMarkdown(w io.Writer, entries []Entry) error {
+	var builder strings.Builder
+
+	_, _ = builder.WriteString("# Error catalog\n")
+
+	var pkg string
+
+	for i, entry := range entries {
+		if i == 0 || entry.Package != pkg {
+			pkg = entry.Package
+
+			_, _ = builder.WriteString("\n## " + pkg + "\n")
+		}
+
+		_, _ = builder.WriteString("\n### " + entry.Name + "\n\n")
+
+		if entry.Summary != "" {
+			_, _ = builder.WriteString(entry.Summary + "\n\n")
+		}
+
+		_, _ = builder.WriteString("- Kind: " + entry.Kind + "\n")
+
+		if entry.Code != "" {
+			_, _ = builder.WriteString("- Code: `" + entry.Code + "`\n")
+		}
+
+		if entry.Message != "" {
+			_, _ = builder.WriteString("- Message: `" + entry.Message + "`\n")
+		}
+
+		_, _ = builder.WriteString("- Defined at: `" + entry.Position + "`\n")
+
+		if entry.Format != "" {
+			_, _ = builder.WriteString("\nFormat:\n\n```\n" + entry.Format + "\n```\n")
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
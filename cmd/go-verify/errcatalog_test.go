@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestLoadCatalog tests that loadCatalog finds every kind of error.
+func TestLoadCatalog(t *testing.T) {
+	entries, err := loadCatalog("testdata/sample")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]Entry, len(entries))
+
+	for _, entry := range entries {
+		found[entry.Name] = entry
+	}
+
+	type args struct {
+		name string
+		want Entry
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			got, ok := found[args.name]
+			if !ok {
+				err := test.FAIL.String("entry", args.name, "")
+				return err
+			}
+
+			got.Position = ""
+			got.Package = ""
+
+			if got == args.want {
+				return nil
+			}
+
+			err := test.FAIL.Any("entry", args.want, got)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("registered code", args{
+		name: "CodeNotFound",
+		want: Entry{
+			Name:    "CodeNotFound",
+			Kind:    KindCode,
+			Code:    "NOT_FOUND",
+			Message: "the resource was not found",
+			Summary: "CodeNotFound is the code of the errors about missing resources.",
+		},
+	})
+
+	_ = tests.Add("code constant", args{
+		name: "CodeTimeout",
+		want: Entry{
+			Name:    "CodeTimeout",
+			Kind:    KindCode,
+			Code:    "TIMEOUT",
+			Summary: "CodeTimeout is the code of the errors about timeouts.",
+		},
+	})
+
+	_ = tests.Add("coded error", args{
+		name: "ErrMissing",
+		want: Entry{
+			Name:    "ErrMissing",
+			Kind:    KindCoded,
+			Code:    "NOT_FOUND",
+			Message: "resource is missing",
+			Summary: "ErrMissing occurs when the resource is missing.",
+			Format:  `"[NOT_FOUND] resource is missing"`,
+		},
+	})
+
+	_ = tests.Add("sentinel", args{
+		name: "ErrClosed",
+		want: Entry{
+			Name:    "ErrClosed",
+			Kind:    KindSentinel,
+			Message: "resource is closed",
+			Summary: "ErrClosed occurs when the resource is closed.",
+			Format:  `"resource is closed"`,
+		},
+	})
+
+	_ = tests.Add("error type", args{
+		name: "ErrBroken",
+		want: Entry{
+			Name:    "ErrBroken",
+			Kind:    KindType,
+			Summary: "ErrBroken occurs when the resource is broken.",
+			Format:  `"resource is broken: <reason>"`,
+		},
+	})
+
+	_ = tests.Run(t)
+
+	_, ok := found["notAnError"]
+	if ok {
+		t.Errorf("want notAnError to be ignored")
+	}
+}
+
+// TestRunErrCatalog tests the errcatalog command on the sample package.
+func TestRunErrCatalog(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := runErrCatalog([]string{"-format", "json", "testdata/sample"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(stdout.String(), "<reason>") {
+		t.Errorf("want the formats to be written without HTML escaping, got %s", stdout.String())
+	}
+
+	var entries []Entry
+
+	err = json.Unmarshal(stdout.Bytes(), &entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+
+		if entry.Package != "github.com/PlayerR9/go-verify/cmd/go-verify/testdata/sample" {
+			t.Errorf("want package github.com/PlayerR9/go-verify/cmd/go-verify/testdata/sample, got %q", entry.Package)
+		}
+	}
+
+	got := strings.Join(names, ",")
+
+	e := test.CHECK.String("entries", "CodeNotFound,CodeTimeout,ErrMissing,ErrClosed,ErrBroken", got)
+	if e != nil {
+		t.Error(e)
+	}
+
+	stdout.Reset()
+
+	err = runErrCatalog([]string{"testdata/sample"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok := strings.Contains(stdout.String(), "### ErrMissing")
+	if !ok {
+		t.Errorf("want the markdown catalog to contain ErrMissing, got:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,95 @@
+// Command go-verify contains tools that work on the errors and assertions of
+// go-verify.
+//
+// Usage:
+//
+//	go-verify <command> [arguments]
+//
+// The commands are:
+//
+//	errcatalog    generate a catalog of the errors defined in Go packages
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a subcommand of go-verify.
+type command struct {
+	// name is the name of the command.
+	name string
+
+	// summary is the one-line description of the command.
+	summary string
+
+	// run runs the command.
+	//
+	// Parameters:
+	//   - args: The arguments of the command, without its name.
+	//   - stdout: The writer of the output.
+	//   - stderr: The writer of the diagnostics.
+	//
+	// Returns:
+	//   - error: An error if the command failed.
+	run func(args []string, stdout, stderr io.Writer) error
+}
+
+var (
+	// commands is the list of available commands.
+	commands []command = []command{
+		{
+			name:    "errcatalog",
+			summary: "generate a catalog of the errors defined in Go packages",
+			run:     runErrCatalog,
+		},
+	}
+)
+
+// usage writes the usage of go-verify.
+//
+// Parameters:
+//   - w: The writer to write to.
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: go-verify <command> [arguments]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "The commands are:")
+	_, _ = fmt.Fprintln(w)
+
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "\t%-12s  %s\n", cmd.name, cmd.summary)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		err := cmd.run(os.Args[2:], os.Stdout, os.Stderr)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, "go-verify "+name+": "+err.Error())
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage(os.Stdout)
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "go-verify: unknown command %q\n\n", name)
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+}
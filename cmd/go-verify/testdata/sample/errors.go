@@ -0,0 +1,61 @@
+package sample
+
+import (
+	"errors"
+
+	"github.com/PlayerR9/go-verify/coded"
+)
+
+// CodeNotFound is the code of the errors about missing resources.
+var CodeNotFound = coded.MustRegister("NOT_FOUND", "the resource was not found")
+
+// CodeTimeout is the code of the errors about timeouts.
+const CodeTimeout coded.Code = "TIMEOUT"
+
+var (
+	// ErrMissing occurs when the resource is missing.
+	//
+	// Format:
+	// 	"[NOT_FOUND] resource is missing"
+	ErrMissing error = coded.New(CodeNotFound, "resource is missing")
+
+	// ErrClosed occurs when the resource is closed.
+	//
+	// Format:
+	// 	"resource is closed"
+	ErrClosed error = errors.New("resource is closed")
+
+	// notAnError is not an error.
+	notAnError = 42
+)
+
+// ErrBroken occurs when the resource is broken.
+type ErrBroken struct {
+	// Reason is why the resource is broken.
+	Reason string
+}
+
+// Error implements error.
+func (e ErrBroken) Error() string {
+	return "resource is broken: " + e.Reason
+}
+
+// NewErrBroken creates a new ErrBroken error.
+//
+// Parameters:
+//   - reason: Why the resource is broken.
+//
+// Returns:
+//   - error: The new error. Never returns nil.
+//
+// Format:
+//
+//	"resource is broken: <reason>"
+//
+// Where:
+//   - <reason> is why the resource is broken.
+func NewErrBroken(reason string) error {
+	return &ErrBroken{
+		Reason: reason,
+	}
+}
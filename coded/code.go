@@ -0,0 +1,130 @@
+package coded
+
+import (
+	"slices"
+	"sync"
+)
+
+// Code is the identity of an error. Two errors with the same code are
+// considered the same error by errors.Is, even if they were created
+// separately.
+type Code string
+
+// String implements fmt.Stringer.
+func (c Code) String() string {
+	return string(c)
+}
+
+// Coder is an error that carries a code.
+type Coder interface {
+	error
+
+	// Code returns the code of the error.
+	//
+	// Returns:
+	//   - Code: The code of the error.
+	Code() Code
+}
+
+var (
+	// registry is the process-wide registry of codes, with their
+	// descriptions.
+	registry map[Code]string = make(map[Code]string)
+
+	// registry_mu protects registry.
+	registry_mu sync.RWMutex
+)
+
+// Register registers a code in the process-wide registry.
+//
+// Parameters:
+//   - code: The code to register.
+//   - desc: The description of the code. It is used as the message of the
+//     errors of this code that have none.
+//
+// Returns:
+//   - error: An error if the code could not be registered.
+//
+// Errors:
+//   - ErrEmptyCode: If the code is empty.
+//   - *ErrDuplicateCode: If the code is already registered.
+func Register(code Code, desc string) error {
+	if code == "" {
+		return ErrEmptyCode
+	}
+
+	registry_mu.Lock()
+	defer registry_mu.Unlock()
+
+	_, ok := registry[code]
+	if ok {
+		err := NewErrDuplicateCode(code)
+		return err
+	}
+
+	registry[code] = desc
+
+	return nil
+}
+
+// MustRegister is like Register but panics if the code could not be
+// registered. It is meant to be used to declare codes at package level.
+//
+// Parameters:
+//   - code: The code to register.
+//   - desc: The description of the code.
+//
+// Returns:
+//   - Code: The registered code.
+//
+// Panics:
+//   - ErrEmptyCode: If the code is empty.
+//   - *ErrDuplicateCode: If the code is already registered.
+//
+// Example:
+//
+//	var CodeNotFound = coded.MustRegister("NOT_FOUND", "the resource was not found")
+func MustRegister(code Code, desc string) Code {
+	err := Register(code, desc)
+	if err != nil {
+		panic(err)
+	}
+
+	return code
+}
+
+// Lookup returns the description of a registered code.
+//
+// Parameters:
+//   - code: The code to look up.
+//
+// Returns:
+//   - string: The description of the code.
+//   - bool: True if the code is registered, false otherwise.
+func Lookup(code Code) (string, bool) {
+	registry_mu.RLock()
+	defer registry_mu.RUnlock()
+
+	desc, ok := registry[code]
+	return desc, ok
+}
+
+// Registered returns every registered code, sorted.
+//
+// Returns:
+//   - []Code: The registered codes.
+func Registered() []Code {
+	registry_mu.RLock()
+
+	codes := make([]Code, 0, len(registry))
+
+	for code := range registry {
+		codes = append(codes, code)
+	}
+
+	registry_mu.RUnlock()
+
+	slices.Sort(codes)
+
+	return codes
+}
@@ -0,0 +1,138 @@
+package coded
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestIs tests that errors.Is matches errors by code.
+func TestIs(t *testing.T) {
+	type args struct {
+		err    error
+		target error
+		want   bool
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			ok := errors.Is(args.err, args.target)
+			if ok == args.want {
+				return nil
+			}
+
+			err := test.FAIL.Any("errors.Is", args.want, ok)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("same code", args{
+		err:    New("A", "foo"),
+		target: New("A", "bar"),
+		want:   true,
+	})
+
+	_ = tests.Add("different code", args{
+		err:    New("A", "foo"),
+		target: New("B", "foo"),
+		want:   false,
+	})
+
+	_ = tests.Add("wrapped cause", args{
+		err:    Wrap("A", os.ErrNotExist, "config.json"),
+		target: os.ErrNotExist,
+		want:   true,
+	})
+
+	_ = tests.Add("code in the chain", args{
+		err:    Wrap("B", New("A", "foo"), ""),
+		target: New("A", ""),
+		want:   true,
+	})
+
+	_ = tests.Add("nil target", args{
+		err:    New("A", "foo"),
+		target: (*ErrCoded)(nil),
+		want:   false,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestError tests the Error method of ErrCoded.
+func TestError(t *testing.T) {
+	type args struct {
+		err  error
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			err := test.CHECK.ErrorMessage("", args.want, args.err)
+			return err
+		}
+
+		return fn
+	}
+
+	_ = Register("TEST_DESC", "described")
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("message", args{
+		err:  New("A", "foo"),
+		want: "[A] foo",
+	})
+
+	_ = tests.Add("wrapped", args{
+		err:  Wrap("A", errors.New("bar"), "foo"),
+		want: "[A] foo: bar",
+	})
+
+	_ = tests.Add("registered description", args{
+		err:  New("TEST_DESC", ""),
+		want: "[TEST_DESC] described",
+	})
+
+	_ = tests.Add("wrap nil", args{
+		err:  Wrap("A", nil, "foo"),
+		want: "",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestRegister tests the Register function.
+func TestRegister(t *testing.T) {
+	err := Register("TEST_REGISTER", "foo")
+
+	e := test.CHECK.Err("", nil, err)
+	if e != nil {
+		t.Error(e)
+	}
+
+	err = Register("TEST_REGISTER", "bar")
+
+	e = test.CHECK.ErrorMessage("", NewErrDuplicateCode("TEST_REGISTER").Error(), err)
+	if e != nil {
+		t.Error(e)
+	}
+
+	err = Register("", "bar")
+
+	e = test.CHECK.Err("", ErrEmptyCode, err)
+	if e != nil {
+		t.Error(e)
+	}
+
+	code, ok := CodeOf(Wrap("TEST_REGISTER", os.ErrClosed, ""))
+	if !ok || code != "TEST_REGISTER" {
+		t.Errorf("want code TEST_REGISTER, got %q", code)
+	}
+}
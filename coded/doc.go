@@ -0,0 +1,11 @@
+// Package coded contains errors that carry a code.
+//
+// A code is the identity of an error: errors.Is considers two errors with the
+// same code to be equal, even if they were created separately. Codes can be
+// declared in a process-wide registry that rejects duplicates.
+//
+//	var CodeNotFound = coded.MustRegister("NOT_FOUND", "the resource was not found")
+//
+//	err := coded.Wrap(CodeNotFound, os.ErrNotExist, "config.json")
+//	errors.Is(err, coded.New(CodeNotFound, "")) // true
+package coded
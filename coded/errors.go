@@ -0,0 +1,222 @@
+package coded
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrEmptyCode occurs when an empty code is registered.
+	//
+	// This error can be checked with the == operator.
+	//
+	// Format:
+	// 	"code must not be empty"
+	ErrEmptyCode error = errors.New("code must not be empty")
+)
+
+// ErrDuplicateCode occurs when a code is registered twice.
+type ErrDuplicateCode struct {
+	// Code is the duplicate code.
+	Code Code
+}
+
+// Error implements error.
+func (e ErrDuplicateCode) Error() string {
+	return "code " + string(e.Code) + " is already registered"
+}
+
+// NewErrDuplicateCode creates and returns a new ErrDuplicateCode error.
+//
+// Parameters:
+//   - code: The duplicate code.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrDuplicateCode. Never returns nil.
+//
+// Format:
+//
+//	"code <code> is already registered"
+//
+// Where:
+//   - <code> is the duplicate code.
+func NewErrDuplicateCode(code Code) error {
+	err := &ErrDuplicateCode{
+		Code: code,
+	}
+
+	return err
+}
+
+// ErrCoded is an error that carries a code. It implements Coder.
+type ErrCoded struct {
+	// ID is the code of the error.
+	ID Code
+
+	// Msg is the error message.
+	Msg string
+
+	// Inner is the error that caused this one, if any.
+	Inner error
+}
+
+// Error implements error.
+func (e ErrCoded) Error() string {
+	msg := e.Msg
+
+	if msg == "" {
+		msg, _ = Lookup(e.ID)
+	}
+
+	if msg == "" {
+		msg = "something went wrong"
+	}
+
+	var builder strings.Builder
+
+	_, _ = builder.WriteRune('[')
+	_, _ = builder.WriteString(string(e.ID))
+	_, _ = builder.WriteString("] ")
+	_, _ = builder.WriteString(msg)
+
+	if e.Inner != nil {
+		_, _ = builder.WriteString(": ")
+		_, _ = builder.WriteString(e.Inner.Error())
+	}
+
+	str := builder.String()
+	return str
+}
+
+// Code implements Coder.
+func (e ErrCoded) Code() Code {
+	return e.ID
+}
+
+// Unwrap returns the error that caused this one.
+//
+// Returns:
+//   - error: The inner error. Nil if there is none.
+func (e ErrCoded) Unwrap() error {
+	return e.Inner
+}
+
+// Is reports whether the target error carries the same code. This makes two
+// separately created errors with the same code equal for errors.Is.
+//
+// Parameters:
+//   - target: The error to compare with.
+//
+// Returns:
+//   - bool: True if the target carries the same code, false otherwise. False
+//     if the target is a nil pointer.
+func (e ErrCoded) Is(target error) bool {
+	other, ok := target.(Coder)
+	if !ok {
+		return false
+	}
+
+	rv := reflect.ValueOf(other)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return false
+	}
+
+	return other.Code() == e.ID
+}
+
+// New creates and returns a new ErrCoded error.
+//
+// Parameters:
+//   - code: The code of the error.
+//   - msg: The error message. If empty, the description of the code is used.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrCoded. Never returns nil.
+//
+// Format:
+//
+//	"[<code>] <msg>"
+//
+// Where:
+//   - <code> is the code of the error.
+//   - <msg> is the error message. If empty, the description of the
+//     registered code is used, or "something went wrong" if there is none.
+func New(code Code, msg string) error {
+	err := &ErrCoded{
+		ID:  code,
+		Msg: msg,
+	}
+
+	return err
+}
+
+// Newf is like New but the message is formatted.
+//
+// Parameters:
+//   - code: The code of the error.
+//   - format: The format string of the error message.
+//   - args: The arguments of the format string.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrCoded. Never returns nil.
+func Newf(code Code, format string, args ...any) error {
+	err := &ErrCoded{
+		ID:  code,
+		Msg: fmt.Sprintf(format, args...),
+	}
+
+	return err
+}
+
+// Wrap creates and returns a new ErrCoded error that wraps a cause.
+//
+// Parameters:
+//   - code: The code of the error.
+//   - inner: The cause of the error.
+//   - msg: The error message. If empty, the description of the code is used.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrCoded. Nil if inner is nil.
+//
+// Format:
+//
+//	"[<code>] <msg>: <inner>"
+//
+// Where:
+//   - <code> is the code of the error.
+//   - <msg> is the error message.
+//   - <inner> is the error message of the cause.
+func Wrap(code Code, inner error, msg string) error {
+	if inner == nil {
+		return nil
+	}
+
+	err := &ErrCoded{
+		ID:    code,
+		Msg:   msg,
+		Inner: inner,
+	}
+
+	return err
+}
+
+// CodeOf returns the code of the first error in the chain that carries one.
+//
+// Parameters:
+//   - err: The error to inspect.
+//
+// Returns:
+//   - Code: The code of the error.
+//   - bool: True if an error of the chain carries a code, false otherwise.
+func CodeOf(err error) (Code, bool) {
+	var c Coder
+
+	ok := errors.As(err, &c)
+	if !ok {
+		return "", false
+	}
+
+	return c.Code(), true
+}
@@ -0,0 +1,336 @@
+package assert
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
+// Len asserts whether the slice has exactly n elements. If not, it panics
+// with an ErrAssertFail error.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - n: The wanted length.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Example:
+//
+//	s := []int{1, 2, 3}
+//	Len(s, 4, "s") // Panics: len(s) = 3, want 4
+func Len[S ~[]E, E any](s S, n int, name string) {
+	if !enabled {
+		return
+	}
+
+	if len(s) == n {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.Len,
+		"name", name,
+		"got", strconv.Itoa(len(s)),
+		"want", strconv.Itoa(n),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// NonEmpty asserts whether the slice has at least one element. If not, it
+// panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Example:
+//
+//	var s []int
+//	NonEmpty(s, "s") // Panics: s is empty
+func NonEmpty[S ~[]E, E any](s S, name string) {
+	if !enabled {
+		return
+	}
+
+	if len(s) > 0 {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	err := newErrAssertFail(message.Format(message.Empty, "name", name))
+	fail(err)
+}
+
+// Contains asserts whether the slice contains the element. If not, it panics
+// with an ErrAssertFail error.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - elem: The wanted element.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Example:
+//
+//	s := []int{1, 2, 3}
+//	Contains(s, 5, "s") // Panics: s does not contain 5
+func Contains[S ~[]E, E comparable](s S, elem E, name string) {
+	if !enabled {
+		return
+	}
+
+	for _, e := range s {
+		if e == elem {
+			return
+		}
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.Contains, "name", name, "elem", fmt.Sprint(elem))
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// NotContains asserts whether the slice does not contain the element. If it
+// does, it panics with an ErrAssertFail error that reports the index of the
+// first occurrence.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - elem: The unwanted element.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Example:
+//
+//	s := []int{1, 2, 5}
+//	NotContains(s, 5, "s") // Panics: s[2] = 5, want != 5
+func NotContains[S ~[]E, E comparable](s S, elem E, name string) {
+	if !enabled {
+		return
+	}
+
+	for i, e := range s {
+		if e != elem {
+			continue
+		}
+
+		if name == "" {
+			name = "variable"
+		}
+
+		msg := message.Format(message.NotContains,
+			"name", name,
+			"index", strconv.Itoa(i),
+			"got", fmt.Sprint(e),
+			"elem", fmt.Sprint(elem),
+		)
+
+		err := newErrAssertFail(msg)
+		fail(err)
+
+		return
+	}
+}
+
+// Unique asserts whether the slice has no duplicate elements. If not, it
+// panics with an ErrAssertFail error that reports the index of the first
+// duplicate and of the element it duplicates.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Example:
+//
+//	s := []int{1, 2, 1}
+//	Unique(s, "s") // Panics: s[2] = 1, duplicate of s[0]
+func Unique[S ~[]E, E comparable](s S, name string) {
+	if !enabled {
+		return
+	}
+
+	seen := make(map[E]int, len(s))
+
+	for i, e := range s {
+		j, ok := seen[e]
+		if !ok {
+			seen[e] = i
+			continue
+		}
+
+		if name == "" {
+			name = "variable"
+		}
+
+		msg := message.Format(message.Unique,
+			"name", name,
+			"index", strconv.Itoa(i),
+			"got", fmt.Sprint(e),
+			"other", strconv.Itoa(j),
+		)
+
+		err := newErrAssertFail(msg)
+		fail(err)
+
+		return
+	}
+}
+
+// Sorted asserts whether the slice is sorted in ascending order. If not, it
+// panics with an ErrAssertFail error that reports the index of the first
+// element that is out of order.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Example:
+//
+//	s := []int{1, 4, 3}
+//	Sorted(s, "s") // Panics: s[2] = 3, want >= 4
+func Sorted[S ~[]E, E cmp.Ordered](s S, name string) {
+	if !enabled {
+		return
+	}
+
+	SortedFunc(s, cmp.Compare[E], name)
+}
+
+// SortedFunc is like Sorted but uses the given comparison function.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - cmp: The comparison function. It must return a negative number when
+//     a < b, a positive number when a > b and zero when a == b.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Panics:
+//   - "parameter (cmp) must not be nil": If cmp is nil.
+//
+// Example:
+//
+//	s := []string{"b", "a"}
+//	SortedFunc(s, strings.Compare, "s") // Panics: s[1] = a, want >= b
+func SortedFunc[S ~[]E, E any](s S, cmp func(a, b E) int, name string) {
+	if !enabled {
+		return
+	}
+
+	if cmp == nil {
+		panic("parameter (cmp) must not be nil")
+	}
+
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i-1], s[i]) <= 0 {
+			continue
+		}
+
+		if name == "" {
+			name = "variable"
+		}
+
+		msg := message.Format(message.Sorted,
+			"name", name,
+			"index", strconv.Itoa(i),
+			"got", fmt.Sprint(s[i]),
+			"prev", fmt.Sprint(s[i-1]),
+		)
+
+		err := newErrAssertFail(msg)
+		fail(err)
+
+		return
+	}
+}
+
+// HasKey asserts whether the map has the key. If not, it panics with an
+// ErrAssertFail error.
+//
+// Parameters:
+//   - m: The map to assert.
+//   - key: The wanted key.
+//   - name: The name of the map. If empty, "variable" is used.
+//
+// Example:
+//
+//	m := map[string]int{"a": 1}
+//	HasKey(m, "b", "m") // Panics: m has no key b
+func HasKey[M ~map[K]V, K comparable, V any](m M, key K, name string) {
+	if !enabled {
+		return
+	}
+
+	_, ok := m[key]
+	if ok {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.HasKey, "name", name, "key", fmt.Sprint(key))
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// AllOf asserts whether every element of the slice satisfies the predicate.
+// If not, it panics with an ErrAssertFail error that reports the index of the
+// first element that does not.
+//
+// Parameters:
+//   - s: The slice to assert.
+//   - pred: The predicate.
+//   - name: The name of the slice. If empty, "variable" is used.
+//
+// Panics:
+//   - "parameter (pred) must not be nil": If pred is nil.
+//
+// Example:
+//
+//	s := []int{1, -2, 3}
+//	AllOf(s, func(n int) bool { return n > 0 }, "s") // Panics: s[1] = -2, want predicate to hold
+func AllOf[S ~[]E, E any](s S, pred func(e E) bool, name string) {
+	if !enabled {
+		return
+	}
+
+	if pred == nil {
+		panic("parameter (pred) must not be nil")
+	}
+
+	for i, e := range s {
+		if pred(e) {
+			continue
+		}
+
+		if name == "" {
+			name = "variable"
+		}
+
+		msg := message.Format(message.AllOf,
+			"name", name,
+			"index", strconv.Itoa(i),
+			"got", fmt.Sprint(e),
+		)
+
+		err := newErrAssertFail(msg)
+		fail(err)
+
+		return
+	}
+}
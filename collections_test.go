@@ -0,0 +1,96 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"strings"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestCollections tests the Len, NonEmpty, Contains, NotContains, Unique,
+// Sorted, SortedFunc, HasKey and AllOf functions.
+func TestCollections(t *testing.T) {
+	type args struct {
+		fn   func()
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Len holds", args{
+		fn:   func() { Len([]int{1, 2, 3}, 3, "s") },
+		want: "",
+	})
+
+	_ = tests.Add("Len fails", args{
+		fn:   func() { Len([]int{1, 2, 3}, 4, "s") },
+		want: NewErrAssertFail("len(s) = 3, want 4").Error(),
+	})
+
+	_ = tests.Add("NonEmpty fails", args{
+		fn:   func() { NonEmpty([]int(nil), "") },
+		want: NewErrAssertFail("variable is empty").Error(),
+	})
+
+	_ = tests.Add("Contains fails", args{
+		fn:   func() { Contains([]int{1, 2, 3}, 5, "s") },
+		want: NewErrAssertFail("s does not contain 5").Error(),
+	})
+
+	_ = tests.Add("NotContains fails", args{
+		fn:   func() { NotContains([]int{1, 2, 5, 5}, 5, "s") },
+		want: NewErrAssertFail("s[2] = 5, want != 5").Error(),
+	})
+
+	_ = tests.Add("Unique holds", args{
+		fn:   func() { Unique([]string{"a", "b"}, "s") },
+		want: "",
+	})
+
+	_ = tests.Add("Unique fails", args{
+		fn:   func() { Unique([]int{1, 2, 1}, "s") },
+		want: NewErrAssertFail("s[2] = 1, duplicate of s[0]").Error(),
+	})
+
+	_ = tests.Add("Sorted holds", args{
+		fn:   func() { Sorted([]int{1, 1, 2}, "s") },
+		want: "",
+	})
+
+	_ = tests.Add("Sorted fails", args{
+		fn:   func() { Sorted([]int{1, 4, 3}, "s") },
+		want: NewErrAssertFail("s[2] = 3, want >= 4").Error(),
+	})
+
+	_ = tests.Add("SortedFunc fails", args{
+		fn:   func() { SortedFunc([]string{"b", "a"}, strings.Compare, "s") },
+		want: NewErrAssertFail("s[1] = a, want >= b").Error(),
+	})
+
+	_ = tests.Add("HasKey fails", args{
+		fn:   func() { HasKey(map[string]int{"a": 1}, "b", "m") },
+		want: NewErrAssertFail("m has no key b").Error(),
+	})
+
+	_ = tests.Add("AllOf fails", args{
+		fn: func() {
+			AllOf([]int{1, -2, 3}, func(n int) bool { return n > 0 }, "s")
+		},
+		want: NewErrAssertFail("s[1] = -2, want predicate to hold").Error(),
+	})
+
+	_ = tests.Run(t)
+}
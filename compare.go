@@ -0,0 +1,257 @@
+package assert
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
+// Equal asserts whether the variable is equal to the wanted value. If not, it
+// panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - want: The wanted value.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	n := 5
+//	Equal(n, 3, "n") // Panics: n = 5, want 3
+func Equal[E comparable](v, want E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v == want {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.Equal,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"want", fmt.Sprint(want),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// NotEqual asserts whether the variable is different from the unwanted
+// value. If not, it panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - unwanted: The unwanted value.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	n := 5
+//	NotEqual(n, 5, "n") // Panics: n = 5, want != 5
+func NotEqual[E comparable](v, unwanted E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v != unwanted {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.NotEqual,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"want", fmt.Sprint(unwanted),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// Less asserts whether the variable is strictly less than the bound. If not,
+// it panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - bound: The exclusive upper bound.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	n := 5
+//	Less(n, 3, "n") // Panics: n = 5, want < 3
+func Less[E cmp.Ordered](v, bound E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v < bound {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.Less,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"bound", fmt.Sprint(bound),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// LessOrEqual asserts whether the variable is less than or equal to the
+// bound. If not, it panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - bound: The inclusive upper bound.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	n := 5
+//	LessOrEqual(n, 3, "n") // Panics: n = 5, want <= 3
+func LessOrEqual[E cmp.Ordered](v, bound E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v <= bound {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.LessOrEqual,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"bound", fmt.Sprint(bound),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// Greater asserts whether the variable is strictly greater than the bound.
+// If not, it panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - bound: The exclusive lower bound.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	n := 1
+//	Greater(n, 3, "n") // Panics: n = 1, want > 3
+func Greater[E cmp.Ordered](v, bound E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v > bound {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.Greater,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"bound", fmt.Sprint(bound),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// InRange asserts whether the variable is in the half-open range [low, high).
+// If not, it panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - low: The inclusive lower bound.
+//   - high: The exclusive upper bound.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	i := 3
+//	InRange(i, 0, 3, "i") // Panics: i = 3, want in [0, 3)
+func InRange[E cmp.Ordered](v, low, high E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v >= low && v < high {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.InRange,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"low", fmt.Sprint(low),
+		"high", fmt.Sprint(high),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
+
+// Between asserts whether the variable is in the closed range [low, high].
+// If not, it panics with an ErrAssertFail error.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - low: The inclusive lower bound.
+//   - high: The inclusive upper bound.
+//   - name: The name of the variable. If empty, "variable" is used.
+//
+// Example:
+//
+//	p := 101
+//	Between(p, 0, 100, "p") // Panics: p = 101, want in [0, 100]
+func Between[E cmp.Ordered](v, low, high E, name string) {
+	if !enabled {
+		return
+	}
+
+	if v >= low && v <= high {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	msg := message.Format(message.Between,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"low", fmt.Sprint(low),
+		"high", fmt.Sprint(high),
+	)
+
+	err := newErrAssertFail(msg)
+	fail(err)
+}
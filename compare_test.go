@@ -0,0 +1,93 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestCompare tests the Equal, NotEqual, Less, LessOrEqual, Greater, InRange
+// and Between functions.
+func TestCompare(t *testing.T) {
+	type args struct {
+		fn   func()
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Equal holds", args{
+		fn:   func() { Equal(3, 3, "n") },
+		want: "",
+	})
+
+	_ = tests.Add("Equal fails", args{
+		fn:   func() { Equal(5, 3, "n") },
+		want: NewErrAssertFail("n = 5, want 3").Error(),
+	})
+
+	_ = tests.Add("NotEqual fails", args{
+		fn:   func() { NotEqual("a", "a", "") },
+		want: NewErrAssertFail("variable = a, want != a").Error(),
+	})
+
+	_ = tests.Add("Less fails", args{
+		fn:   func() { Less(5, 3, "n") },
+		want: NewErrAssertFail("n = 5, want < 3").Error(),
+	})
+
+	_ = tests.Add("Less fails on equal", args{
+		fn:   func() { Less(3, 3, "n") },
+		want: NewErrAssertFail("n = 3, want < 3").Error(),
+	})
+
+	_ = tests.Add("LessOrEqual holds on equal", args{
+		fn:   func() { LessOrEqual(3, 3, "n") },
+		want: "",
+	})
+
+	_ = tests.Add("LessOrEqual fails", args{
+		fn:   func() { LessOrEqual(5, 3, "n") },
+		want: NewErrAssertFail("n = 5, want <= 3").Error(),
+	})
+
+	_ = tests.Add("Greater fails", args{
+		fn:   func() { Greater(1.5, 3.0, "x") },
+		want: NewErrAssertFail("x = 1.5, want > 3").Error(),
+	})
+
+	_ = tests.Add("InRange holds", args{
+		fn:   func() { InRange(0, 0, 3, "i") },
+		want: "",
+	})
+
+	_ = tests.Add("InRange fails on high", args{
+		fn:   func() { InRange(3, 0, 3, "i") },
+		want: NewErrAssertFail("i = 3, want in [0, 3)").Error(),
+	})
+
+	_ = tests.Add("Between holds on high", args{
+		fn:   func() { Between(100, 0, 100, "p") },
+		want: "",
+	})
+
+	_ = tests.Add("Between fails", args{
+		fn:   func() { Between(-1, 0, 100, "p") },
+		want: NewErrAssertFail("p = -1, want in [0, 100]").Error(),
+	})
+
+	_ = tests.Run(t)
+}
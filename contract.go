@@ -0,0 +1,145 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Require asserts a precondition of a function: a condition that the caller
+// must satisfy. If it is not met, it panics with an ErrPrecondition error,
+// which means that the caller broke the contract.
+//
+// Parameters:
+//   - cond: The precondition to check.
+//   - format: The format string for the error message.
+//   - args: The arguments to use with the format string.
+//
+// Panics:
+//   - ErrPrecondition: If the precondition is not met.
+//
+// Example:
+//
+//	func Sqrt(x float64) float64 {
+//		Require(x >= 0, "x = %v, want >= 0", x)
+//
+//		// ...
+//	}
+func Require(cond bool, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
+	if cond {
+		return
+	}
+
+	err := newErrAssertFail(fmt.Sprintf(format, args...))
+
+	h := *handler.Load()
+	raise(h, err, &ErrPrecondition{
+		Fail: err,
+	})
+}
+
+// Ensure asserts a postcondition of a function: a condition that the
+// function itself must satisfy before returning. If it is not met, it panics
+// with an ErrPostcondition error, which means that the callee broke the
+// contract.
+//
+// Parameters:
+//   - cond: The postcondition to check.
+//   - format: The format string for the error message.
+//   - args: The arguments to use with the format string.
+//
+// Panics:
+//   - ErrPostcondition: If the postcondition is not met.
+//
+// Example:
+//
+//	func (s *Stack) Push(e int) {
+//		before := Old(s.elems)
+//		defer func() {
+//			Ensure(len(s.elems) == len(before)+1, "len(s.elems) = %d, want %d", len(s.elems), len(before)+1)
+//		}()
+//
+//		// ...
+//	}
+func Ensure(cond bool, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
+	if cond {
+		return
+	}
+
+	err := newErrAssertFail(fmt.Sprintf(format, args...))
+
+	h := *handler.Load()
+	raise(h, err, &ErrPostcondition{
+		Fail: err,
+	})
+}
+
+// Old snapshots a value at function entry so that a postcondition can
+// compare the old and new states. Slices, maps and pointers are copied one
+// level deep: their elements are copied but not the values they refer to.
+// Any other value is returned as-is, since it is already a copy.
+//
+// Under the "verify_noassert" build tag, v is returned without copying.
+//
+// Parameters:
+//   - v: The value to snapshot.
+//
+// Returns:
+//   - T: The snapshot.
+//
+// Example:
+//
+//	before := Old(list)
+//	list = append(list, 1)
+//
+//	Ensure(len(list) == len(before)+1, "len(list) = %d, want %d", len(list), len(before)+1)
+func Old[T any](v T) T {
+	if !enabled {
+		return v
+	}
+
+	rv := reflect.ValueOf(&v).Elem()
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			break
+		}
+
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(c, rv)
+
+		rv.Set(c)
+	case reflect.Map:
+		if rv.IsNil() {
+			break
+		}
+
+		c := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+
+		iter := rv.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		rv.Set(c)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			break
+		}
+
+		c := reflect.New(rv.Type().Elem())
+		c.Elem().Set(rv.Elem())
+
+		rv.Set(c)
+	}
+
+	return v
+}
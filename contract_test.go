@@ -0,0 +1,120 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestContract tests the Require, Ensure and Old functions.
+func TestContract(t *testing.T) {
+	type args struct {
+		fn        func()
+		want      string
+		want_post bool
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			if err != nil || caught == nil {
+				return err
+			}
+
+			var af *ErrAssertFail
+
+			ok := errors.As(caught, &af)
+			if !ok {
+				err := test.FAIL.String("error type", "*assert.ErrAssertFail", "none")
+				return err
+			}
+
+			var post *ErrPostcondition
+
+			ok = errors.As(caught, &post)
+			if ok != args.want_post {
+				err := test.FAIL.Any("postcondition", args.want_post, ok)
+				return err
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	push := func(list []int, broken bool) []int {
+		before := Old(list)
+
+		if !broken {
+			list = append(list, 1)
+		}
+
+		Ensure(len(list) == len(before)+1, "len(list) = %d, want %d", len(list), len(before)+1)
+
+		return list
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Require holds", args{
+		fn:   func() { Require(true, "x < 0") },
+		want: "",
+	})
+
+	_ = tests.Add("Require fails", args{
+		fn:        func() { Require(false, "x = %d, want >= 0", -1) },
+		want:      "[PRECONDITION FAIL]: x = -1, want >= 0",
+		want_post: false,
+	})
+
+	_ = tests.Add("Ensure holds", args{
+		fn:   func() { _ = push([]int{1, 2}, false) },
+		want: "",
+	})
+
+	_ = tests.Add("Ensure fails", args{
+		fn:        func() { _ = push([]int{1, 2}, true) },
+		want:      "[POSTCONDITION FAIL]: len(list) = 2, want 3",
+		want_post: true,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestOld tests that Old copies slices, maps and pointers.
+func TestOld(t *testing.T) {
+	s := []int{1, 2}
+	old_s := Old(s)
+	s[0] = 42
+
+	err := test.CHECK.Int("old_s[0]", 1, old_s[0])
+	if err != nil {
+		t.Error(err)
+	}
+
+	m := map[string]int{"a": 1}
+	old_m := Old(m)
+	m["a"] = 42
+
+	err = test.CHECK.Int(`old_m["a"]`, 1, old_m["a"])
+	if err != nil {
+		t.Error(err)
+	}
+
+	type point struct{ X int }
+
+	p := &point{X: 1}
+	old_p := Old(p)
+	p.X = 42
+
+	err = test.CHECK.Int("old_p.X", 1, old_p.X)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,321 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
+const (
+	// maxDiffs is the maximum number of differences listed by DeepEqual.
+	maxDiffs int = 10
+)
+
+// DeepEqual asserts whether got and want are deeply equal, in the sense of
+// reflect.DeepEqual. If not, it panics with an ErrAssertFail error that lists
+// the path of each difference, up to 10 of them.
+//
+// Structs, arrays, slices, maps, pointers and interfaces are walked
+// recursively, including unexported struct fields.
+//
+// Parameters:
+//   - got: The actual value.
+//   - want: The wanted value.
+//   - name: The name of the value. If empty, "variable" is used.
+//
+// Example:
+//
+//	got := Config{Servers: []Server{{Port: 80}}}
+//	want := Config{Servers: []Server{{Port: 8080}}}
+//
+//	DeepEqual(got, want, "cfg") // Panics: cfg.Servers[0].Port: 80 != 8080
+func DeepEqual(got, want any, name string) {
+	if !enabled {
+		return
+	}
+
+	if reflect.DeepEqual(got, want) {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	diffs := deepDiff(got, want, name)
+
+	err := newErrAssertFail(joinDiffs(diffs))
+	fail(err)
+}
+
+// joinDiffs joins the given differences into a single message. At most
+// maxDiffs differences are listed.
+//
+// Parameters:
+//   - diffs: The differences.
+//
+// Returns:
+//   - string: The message.
+func joinDiffs(diffs []string) string {
+	if len(diffs) <= maxDiffs {
+		str := strings.Join(diffs, "; ")
+		return str
+	}
+
+	str := strings.Join(diffs[:maxDiffs], "; ")
+	str += "; " + message.Format(message.DeepMore, "count", strconv.Itoa(len(diffs)-maxDiffs))
+
+	return str
+}
+
+// deepDiff returns the differences between got and want.
+//
+// Parameters:
+//   - got: The actual value.
+//   - want: The wanted value.
+//   - name: The name of the root of the paths.
+//
+// Returns:
+//   - []string: The differences, formatted as "<path>: <got> != <want>".
+func deepDiff(got, want any, name string) []string {
+	d := &differ{
+		visited: make(map[visit]struct{}),
+	}
+
+	d.walk(name, reflect.ValueOf(got), reflect.ValueOf(want))
+
+	return d.diffs
+}
+
+// visit is a pair of pointers that is being compared. It is used to stop
+// on cyclic structures.
+type visit struct {
+	// got is the pointer of the actual value.
+	got uintptr
+
+	// want is the pointer of the wanted value.
+	want uintptr
+
+	// typ is the type of the pointers.
+	typ reflect.Type
+}
+
+// differ collects the differences between two values.
+type differ struct {
+	// diffs is the list of differences found so far.
+	diffs []string
+
+	// visited is the set of pointer pairs already compared.
+	visited map[visit]struct{}
+}
+
+// report adds a difference.
+//
+// Parameters:
+//   - path: The path of the difference.
+//   - got: The formatted actual value.
+//   - want: The formatted wanted value.
+func (d *differ) report(path, got, want string) {
+	d.diffs = append(d.diffs, path+": "+got+" != "+want)
+}
+
+// walk compares got and want recursively.
+//
+// Parameters:
+//   - path: The path of the values.
+//   - got: The actual value.
+//   - want: The wanted value.
+func (d *differ) walk(path string, got, want reflect.Value) {
+	if !got.IsValid() || !want.IsValid() {
+		if got.IsValid() != want.IsValid() {
+			d.report(path, formatValue(got), formatValue(want))
+		}
+
+		return
+	}
+
+	if got.Type() != want.Type() {
+		d.report(path, got.Type().String(), want.Type().String())
+		return
+	}
+
+	switch got.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				d.report(path, formatValue(got), formatValue(want))
+			}
+
+			return
+		}
+
+		if got.Kind() == reflect.Slice && got.Len() != want.Len() {
+			break
+		}
+
+		v := visit{
+			got:  got.Pointer(),
+			want: want.Pointer(),
+			typ:  got.Type(),
+		}
+
+		if v.got == v.want {
+			return
+		}
+
+		_, ok := d.visited[v]
+		if ok {
+			return
+		}
+
+		d.visited[v] = struct{}{}
+	}
+
+	switch got.Kind() {
+	case reflect.Pointer:
+		d.walk(path, got.Elem(), want.Elem())
+	case reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				d.report(path, formatValue(got), formatValue(want))
+			}
+
+			return
+		}
+
+		d.walk(path, got.Elem(), want.Elem())
+	case reflect.Struct:
+		typ := got.Type()
+
+		for i := 0; i < typ.NumField(); i++ {
+			d.walk(path+"."+typ.Field(i).Name, got.Field(i), want.Field(i))
+		}
+	case reflect.Array:
+		for i := 0; i < got.Len(); i++ {
+			d.walk(path+"["+strconv.Itoa(i)+"]", got.Index(i), want.Index(i))
+		}
+	case reflect.Slice:
+		n := min(got.Len(), want.Len())
+
+		for i := 0; i < n; i++ {
+			d.walk(path+"["+strconv.Itoa(i)+"]", got.Index(i), want.Index(i))
+		}
+
+		for i := n; i < got.Len(); i++ {
+			d.report(path+"["+strconv.Itoa(i)+"]", formatValue(got.Index(i)), "<missing>")
+		}
+
+		for i := n; i < want.Len(); i++ {
+			d.report(path+"["+strconv.Itoa(i)+"]", "<missing>", formatValue(want.Index(i)))
+		}
+	case reflect.Map:
+		keys := mapKeys(got, want)
+
+		for _, key := range keys {
+			key_path := path + "[" + formatValue(key) + "]"
+
+			got_elem := got.MapIndex(key)
+			want_elem := want.MapIndex(key)
+
+			switch {
+			case !got_elem.IsValid():
+				d.report(key_path, "<missing>", formatValue(want_elem))
+			case !want_elem.IsValid():
+				d.report(key_path, formatValue(got_elem), "<missing>")
+			default:
+				d.walk(key_path, got_elem, want_elem)
+			}
+		}
+	case reflect.Func:
+		if !got.IsNil() || !want.IsNil() {
+			d.report(path, formatValue(got), formatValue(want))
+		}
+	default:
+		if !basicEqual(got, want) {
+			d.report(path, formatValue(got), formatValue(want))
+		}
+	}
+}
+
+// mapKeys returns the union of the keys of two maps of the same type, sorted
+// by their formatted value.
+//
+// Parameters:
+//   - a: The first map.
+//   - b: The second map.
+//
+// Returns:
+//   - []reflect.Value: The sorted keys.
+func mapKeys(a, b reflect.Value) []reflect.Value {
+	keys := a.MapKeys()
+
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, func(x, y reflect.Value) int {
+		return strings.Compare(formatValue(x), formatValue(y))
+	})
+
+	return keys
+}
+
+// basicEqual compares two values of the same basic kind.
+//
+// Parameters:
+//   - a: The first value.
+//   - b: The second value.
+//
+// Returns:
+//   - bool: True if the values are equal, false otherwise.
+func basicEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	default:
+		return false
+	}
+}
+
+// formatValue formats a value for a difference. Strings are quoted.
+//
+// Parameters:
+//   - v: The value to format.
+//
+// Returns:
+//   - string: The formatted value.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+
+	str := fmt.Sprintf("%v", v)
+	return str
+}
@@ -0,0 +1,118 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestDeepEqual tests the DeepEqual function.
+func TestDeepEqual(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+
+	type config struct {
+		Name    string
+		Servers []*server
+		Labels  map[string]string
+		parent  *config
+	}
+
+	type args struct {
+		got  any
+		want any
+		msg  string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(func() {
+				DeepEqual(args.got, args.want, "cfg")
+			})
+
+			var want string
+
+			if args.msg != "" {
+				want = NewErrAssertFail(args.msg).Error()
+			}
+
+			err := test.CHECK.ErrorMessage("", want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("equal", args{
+		got:  config{Name: "a", Servers: []*server{{Port: 80}}},
+		want: config{Name: "a", Servers: []*server{{Port: 80}}},
+		msg:  "",
+	})
+
+	_ = tests.Add("nested field", args{
+		got:  config{Servers: []*server{{Port: 1}, {Port: 2}, {Port: 80}}},
+		want: config{Servers: []*server{{Port: 1}, {Port: 2}, {Port: 8080}}},
+		msg:  "cfg.Servers[2].Port: 80 != 8080",
+	})
+
+	_ = tests.Add("several differences", args{
+		got:  config{Name: "a", Labels: map[string]string{"x": "1", "y": "2"}},
+		want: config{Name: "b", Labels: map[string]string{"x": "1", "z": "2"}},
+		msg:  `cfg.Name: "a" != "b"; cfg.Labels["y"]: "2" != <missing>; cfg.Labels["z"]: <missing> != "2"`,
+	})
+
+	_ = tests.Add("slice length", args{
+		got:  []int{1, 2},
+		want: []int{1, 2, 3},
+		msg:  "cfg[2]: <missing> != 3",
+	})
+
+	_ = tests.Add("unexported field", args{
+		got:  config{parent: &config{Name: "a"}},
+		want: config{parent: &config{Name: "b"}},
+		msg:  `cfg.parent.Name: "a" != "b"`,
+	})
+
+	_ = tests.Add("nil pointer", args{
+		got:  config{parent: nil},
+		want: config{parent: &config{}},
+		msg:  "cfg.parent: nil != &{ [] map[] <nil>}",
+	})
+
+	_ = tests.Add("different types", args{
+		got:  1,
+		want: "1",
+		msg:  "cfg: int != string",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestDeepEqualCycle tests that DeepEqual terminates on cyclic values.
+func TestDeepEqualCycle(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+
+	a := &node{Value: 1}
+	a.Next = a
+
+	b := &node{Value: 1}
+	b.Next = b
+
+	caught := test.Try(func() {
+		DeepEqual(a, b, "n")
+	})
+
+	err := test.CHECK.ErrorMessage("", "", caught)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,19 @@
+// Package assert contains helping functions for assertions.
+//
+// By default, a failed assertion panics with an *ErrAssertFail. The behavior
+// can be changed at runtime with SetMode or the VERIFY_ASSERT_MODE
+// environment variable ("panic", "log" or "count"), or replaced entirely by
+// a Handler set with SetHandler or carried by a context with WithHandler.
+//
+// Every failure has a Kind, retrieved with KindOf, that tells an internal bug
+// (KindBug, KindUnreachable) apart from bad input that reached an assertion.
+// FoundBug and Unreachable always panic, regardless of the mode.
+//
+// Public entry points can defer Recover, or use Catch, to return assertion
+// failures as errors instead of panicking on their callers.
+//
+// When built with the "verify_noassert" build tag, every assertion compiles
+// down to a no-op:
+//
+//	go build -tags verify_noassert ./...
+package assert
@@ -1,9 +1,39 @@
 package assert
 
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
 // ErrAssertFail occurs when an assertion is not met.
 type ErrAssertFail struct {
 	// Msg is the error message.
 	Msg string
+
+	// Inner is the error that caused the failure, if any.
+	Inner error
+
+	// Kind is the category of the failure.
+	Kind Kind
+
+	// File is the file of the assertion caller. Empty if unknown.
+	File string
+
+	// Line is the line of the assertion caller. Zero if unknown.
+	Line int
+
+	// Func is the fully qualified name of the function that called the
+	// assertion. Empty if unknown.
+	Func string
+
+	// Stack is the captured stack trace, starting from the assertion caller.
+	// Nil if stack capture is disabled.
+	Stack []Frame
 }
 
 // Error implements error.
@@ -13,14 +43,60 @@ func (e ErrAssertFail) Error() string {
 	if e.Msg != "" {
 		msg = e.Msg
 	} else {
-		msg = "an assertion was not met"
+		msg = message.Format(message.AssertDefault)
 	}
 
-	return "[ASSERT FAIL]: " + msg
+	str := message.Format(message.AssertFail, "msg", msg)
+	return str
+}
+
+// Unwrap returns the error that caused the failure.
+//
+// Returns:
+//   - error: The inner error. Nil if the failure has no cause.
+func (e ErrAssertFail) Unwrap() error {
+	return e.Inner
+}
+
+// Format implements fmt.Formatter.
+//
+// The verbs %s and %v print the error message. The verb %+v additionally
+// prints the location of the assertion caller and the captured stack trace,
+// if any. The verb %q prints the quoted error message.
+func (e ErrAssertFail) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, e.Error())
+
+		if !s.Flag('+') {
+			return
+		}
+
+		if len(e.Stack) > 0 {
+			for _, frame := range e.Stack {
+				_, _ = io.WriteString(s, "\n"+frame.String())
+			}
+		} else if e.File != "" {
+			caller := Frame{
+				Func: e.Func,
+				File: e.File,
+				Line: e.Line,
+			}
+
+			_, _ = io.WriteString(s, "\n"+caller.String())
+		}
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(assert.ErrAssertFail=%s)", verb, e.Error())
+	}
 }
 
 // NewErrAssertFail creates and returns a new ErrAssertFail error with the
-// specified error message.
+// specified error message. The location of the caller, skipping the frames
+// of this package, is recorded in the error.
 //
 // Parameters:
 //   - msg: The error message.
@@ -36,9 +112,548 @@ func (e ErrAssertFail) Error() string {
 //   - <msg> is the error message. If empty, defaults to "an assertion was not
 //     met".
 func NewErrAssertFail(msg string) error {
+	err := newErrAssertFail(msg)
+	return err
+}
+
+// NewErrAssertFailWith is like NewErrAssertFail but the error also wraps the
+// given cause, which is reachable through errors.Unwrap.
+//
+// Parameters:
+//   - msg: The error message.
+//   - inner: The cause of the failure.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrAssertFail. Never returns nil.
+//
+// Format:
+//
+//	"[ASSERT FAIL]: <msg>"
+//
+// Where:
+//   - <msg> is the error message. If empty, defaults to "an assertion was not
+//     met".
+func NewErrAssertFailWith(msg string, inner error) error {
+	err := newErrAssertFail(msg)
+	err.Inner = inner
+
+	return err
+}
+
+// newErrAssertFail is like NewErrAssertFail but returns the concrete type.
+//
+// Parameters:
+//   - msg: The error message.
+//
+// Returns:
+//   - *ErrAssertFail: The newly created error. Never returns nil.
+func newErrAssertFail(msg string) *ErrAssertFail {
 	err := &ErrAssertFail{
 		Msg: msg,
 	}
 
+	frames := callers()
+	if len(frames) == 0 {
+		return err
+	}
+
+	err.File = frames[0].File
+	err.Line = frames[0].Line
+	err.Func = frames[0].Func
+
+	if stack_capture.Load() {
+		err.Stack = frames
+	}
+
+	return err
+}
+
+// ErrValidateFailed is an error that is returned when a validation fails.
+type ErrValidateFailed struct {
+	// Name is the name, or path, of the variable. If empty, "struct" is used.
+	Name string
+
+	// Reason describes what went wrong.
+	Reason error
+}
+
+// Error implements the error interface.
+func (e ErrValidateFailed) Error() string {
+	var name string
+
+	if e.Name == "" {
+		name = "struct"
+	} else {
+		name = e.Name
+	}
+
+	var reason string
+
+	if e.Reason == nil {
+		reason = "nil"
+	} else {
+		reason = e.Reason.Error()
+	}
+
+	str := message.Format(message.ValidateFail, "name", name, "reason", reason)
+	return str
+}
+
+// Unwrap returns the reason of the failure.
+//
+// Returns:
+//   - error: The reason of the failure. Nil if the struct was nil.
+func (e ErrValidateFailed) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrValidateFailed creates a new ErrValidateFailed.
+//
+// Parameters:
+//   - name: The name of the variable.
+//   - reason: The message of the error.
+//
+// Returns:
+//   - error: The new error. Never returns nil.
+//
+// Format:
+//
+//	(Validate Failed) "<name> = <reason>"
+//
+// where:
+//   - <name> is the name of the variable. If empty, "struct" is used.
+//   - <reason> is the message of the error.
+func NewErrValidateFailed(name string, reason error) error {
+	return &ErrValidateFailed{
+		Name:   name,
+		Reason: reason,
+	}
+}
+
+// ErrValidateFailures is an error that aggregates every validation failure
+// found by ValidateAll. It is compatible with errors.Is and errors.As, which
+// look into each failure.
+type ErrValidateFailures struct {
+	// Failures is the list of failures, in the order they were found.
+	Failures []*ErrValidateFailed
+}
+
+// Error implements the error interface.
+func (e ErrValidateFailures) Error() string {
+	if len(e.Failures) == 0 {
+		str := message.Format(message.ValidateDefault)
+		return str
+	}
+
+	var builder strings.Builder
+
+	for i, failure := range e.Failures {
+		if i > 0 {
+			_, _ = builder.WriteRune('\n')
+		}
+
+		_, _ = builder.WriteString(failure.Error())
+	}
+
+	str := builder.String()
+	return str
+}
+
+// Unwrap returns every failure.
+//
+// Returns:
+//   - []error: The failures.
+func (e ErrValidateFailures) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+
+	for _, failure := range e.Failures {
+		errs = append(errs, failure)
+	}
+
+	return errs
+}
+
+// ErrFixFailed is an error that is returned when a fix fails.
+type ErrFixFailed struct {
+	// Name is the name of the variable. If empty, "struct" is used.
+	Name string
+
+	// Reason describes what went wrong.
+	Reason error
+}
+
+// Error implements the error interface.
+func (e ErrFixFailed) Error() string {
+	var name string
+
+	if e.Name == "" {
+		name = "struct"
+	} else {
+		name = e.Name
+	}
+
+	var reason string
+
+	if e.Reason == nil {
+		reason = "nil"
+	} else {
+		reason = e.Reason.Error()
+	}
+
+	str := message.Format(message.FixFail, "name", name, "reason", reason)
+	return str
+}
+
+// Unwrap returns the reason of the failure.
+//
+// Returns:
+//   - error: The reason of the failure. Nil if the struct was nil.
+func (e ErrFixFailed) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrFixFailed creates a new ErrFixFailed.
+//
+// Parameters:
+//   - name: The name of the variable.
+//   - reason: The message of the error.
+//
+// Returns:
+//   - error: The new error. Never returns nil.
+//
+// Format:
+//
+//	(Fix Failed) "<name> = <reason>"
+//
+// where:
+//   - <name> is the name of the variable. If empty, "struct" is used.
+//   - <reason> is the message of the error.
+func NewErrFixFailed(name string, reason error) error {
+	return &ErrFixFailed{
+		Name:   name,
+		Reason: reason,
+	}
+}
+
+// ErrPrecondition occurs when a precondition checked by Require is not met.
+// It means that the caller of the function broke the contract.
+type ErrPrecondition struct {
+	// Fail is the underlying assertion failure.
+	Fail *ErrAssertFail
+}
+
+// Error implements error.
+func (e ErrPrecondition) Error() string {
+	var msg string
+
+	if e.Fail != nil && e.Fail.Msg != "" {
+		msg = e.Fail.Msg
+	} else {
+		msg = message.Format(message.PreconditionDefault)
+	}
+
+	str := message.Format(message.Precondition, "msg", msg)
+	return str
+}
+
+// Unwrap returns the underlying assertion failure.
+//
+// Returns:
+//   - error: The underlying *ErrAssertFail. Nil if there is none.
+func (e ErrPrecondition) Unwrap() error {
+	if e.Fail == nil {
+		return nil
+	}
+
+	return e.Fail
+}
+
+// ErrPostcondition occurs when a postcondition checked by Ensure is not met.
+// It means that the function itself broke the contract.
+type ErrPostcondition struct {
+	// Fail is the underlying assertion failure.
+	Fail *ErrAssertFail
+}
+
+// Error implements error.
+func (e ErrPostcondition) Error() string {
+	var msg string
+
+	if e.Fail != nil && e.Fail.Msg != "" {
+		msg = e.Fail.Msg
+	} else {
+		msg = message.Format(message.PostconditionDefault)
+	}
+
+	str := message.Format(message.Postcondition, "msg", msg)
+	return str
+}
+
+// Unwrap returns the underlying assertion failure.
+//
+// Returns:
+//   - error: The underlying *ErrAssertFail. Nil if there is none.
+func (e ErrPostcondition) Unwrap() error {
+	if e.Fail == nil {
+		return nil
+	}
+
+	return e.Fail
+}
+
+// ErrSoftFail occurs when at least one soft assertion of a scope failed. Like
+// the errors returned by errors.Join, its message has one line per failure
+// and it unwraps to every failure.
+type ErrSoftFail struct {
+	// Failures is the list of failures, in the order they were recorded.
+	Failures []*ErrAssertFail
+}
+
+// Error implements error.
+func (e ErrSoftFail) Error() string {
+	if len(e.Failures) == 0 {
+		str := ErrAssertFail{}.Error()
+		return str
+	}
+
+	var builder strings.Builder
+
+	for i, failure := range e.Failures {
+		if i > 0 {
+			_, _ = builder.WriteRune('\n')
+		}
+
+		_, _ = builder.WriteString(failure.Error())
+	}
+
+	str := builder.String()
+	return str
+}
+
+// Unwrap returns every failure.
+//
+// Returns:
+//   - []error: The failures.
+func (e ErrSoftFail) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+
+	for _, failure := range e.Failures {
+		errs = append(errs, failure)
+	}
+
+	return errs
+}
+
+// Field is a key/value pair that gives context to an error.
+type Field struct {
+	// Key is the name of the field.
+	Key string
+
+	// Value is the value of the field.
+	Value any
+}
+
+// String implements fmt.Stringer.
+func (f Field) String() string {
+	return f.Key + "=" + fmt.Sprint(f.Value)
+}
+
+// ErrBug occurs when FoundBug is called. It means that the program reached
+// a state that is only possible because of a bug.
+type ErrBug struct {
+	// Msg is the description of the bug.
+	Msg string
+
+	// Context is the context of the bug, in the order it was given.
+	Context []Field
+
+	// Fail is the underlying assertion failure, of kind KindBug.
+	Fail *ErrAssertFail
+}
+
+// Error implements error.
+func (e ErrBug) Error() string {
+	if len(e.Context) == 0 {
+		str := message.Format(message.Bug, "msg", e.Msg)
+		return str
+	}
+
+	fields := make([]string, 0, len(e.Context))
+
+	for _, field := range e.Context {
+		fields = append(fields, field.String())
+	}
+
+	str := message.Format(message.BugContext,
+		"msg", e.Msg,
+		"context", strings.Join(fields, ", "),
+	)
+
+	return str
+}
+
+// Unwrap returns the underlying assertion failure.
+//
+// Returns:
+//   - error: The underlying *ErrAssertFail. Nil if there is none.
+func (e ErrBug) Unwrap() error {
+	if e.Fail == nil {
+		return nil
+	}
+
+	return e.Fail
+}
+
+// Value returns the value of the context field with the given key.
+//
+// Parameters:
+//   - key: The key of the field.
+//
+// Returns:
+//   - any: The value of the first field with the key. Nil if there is none.
+//   - bool: True if the field exists, false otherwise.
+func (e ErrBug) Value(key string) (any, bool) {
+	for _, field := range e.Context {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// NewErrBug creates and returns a new ErrBug error with the given message
+// and context. The location of the caller, skipping the frames of this
+// package, is recorded in the underlying failure.
+//
+// Parameters:
+//   - msg: The description of the bug.
+//   - kv: The context of the bug, as alternating keys and values.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrBug. Never returns nil.
+//
+// Format:
+//
+//	"[bug] <msg> (<key>=<value>, ...)"
+//
+// Where:
+//   - <msg> is the description of the bug.
+//   - <key>=<value> are the context fields. The parentheses are omitted if
+//     there is no context.
+func NewErrBug(msg string, kv ...any) error {
+	af := newErrAssertFail(msg)
+	af.Kind = KindBug
+
+	err := &ErrBug{
+		Msg:     msg,
+		Context: makeFields(kv),
+		Fail:    af,
+	}
+
+	return err
+}
+
+// ErrPanic occurs when a panic that is not an assertion failure is recovered
+// by RecoverAny or CatchAny. It has the same fields and message as the
+// ErrPanic of the test package.
+type ErrPanic struct {
+	// Value is the value of the panic.
+	Value any
+
+	// Stack is the stack trace of the panic, starting from the function that
+	// panicked. Each frame takes two lines, "<func>" and "\t<file>:<line>", as
+	// in the traces of runtime/debug.Stack. Empty if it could not be captured.
+	Stack string
+
+	// Runtime is whether the value of the panic is a runtime.Error, such as
+	// an index out of range or a nil pointer dereference.
+	Runtime bool
+
+	// NilPanic is whether the panic was a panic(nil), reported by the runtime
+	// as a *runtime.PanicNilError.
+	NilPanic bool
+}
+
+// Error implements error.
+func (e ErrPanic) Error() string {
+	str := message.Format(message.Panic, "value", fmt.Sprint(e.Value))
+	return str
+}
+
+// Unwrap returns the value of the panic if it is an error, such as a
+// runtime.Error.
+//
+// Returns:
+//   - error: The value of the panic. Nil if it is not an error.
+func (e ErrPanic) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Format implements fmt.Formatter.
+//
+// The verbs %s and %v print the error message. The verb %+v additionally
+// prints the stack trace of the panic. The verb %q prints the quoted error
+// message.
+func (e ErrPanic) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, e.Error())
+
+		if s.Flag('+') && e.Stack != "" {
+			_, _ = io.WriteString(s, "\n\n"+e.Stack)
+		}
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(assert.ErrPanic=%s)", verb, e.Error())
+	}
+}
+
+// NewErrPanic creates and returns a new ErrPanic error with the given panic
+// value and no stack trace.
+//
+// Parameters:
+//   - value: The value of the panic.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrPanic. Never returns nil.
+//
+// Format:
+//
+//	"panic: <value>"
+//
+// Where:
+//   - <value> is the value of the panic, formatted with %v.
+func NewErrPanic(value any) error {
+	err := newErrPanic(value, "")
+	return err
+}
+
+// newErrPanic creates a new ErrPanic error and classifies its value.
+//
+// Parameters:
+//   - value: The value of the panic.
+//   - stack: The stack trace of the panic.
+//
+// Returns:
+//   - *ErrPanic: The new error. Never returns nil.
+func newErrPanic(value any, stack string) *ErrPanic {
+	err := &ErrPanic{
+		Value: value,
+		Stack: stack,
+	}
+
+	switch value.(type) {
+	case *runtime.PanicNilError:
+		err.Runtime = true
+		err.NilPanic = true
+	case runtime.Error:
+		err.Runtime = true
+	}
+
 	return err
 }
@@ -0,0 +1,169 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/go-verify/message"
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// failCond is a named caller of Cond, so that its location is stable.
+func failCond() {
+	Cond(false, "foo")
+}
+
+// failNotZero is a named caller of NotZero, so that its location is stable.
+func failNotZero() {
+	NotZero(0, "v")
+}
+
+// TestErrAssertFailCaller tests that ErrAssertFail records the location of
+// the assertion caller.
+func TestErrAssertFailCaller(t *testing.T) {
+	type args struct {
+		fn       func()
+		want_fn  string
+		want_msg string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			var af *ErrAssertFail
+
+			ok := errors.As(caught, &af)
+			if !ok {
+				err := test.FAIL.Err("error", NewErrAssertFail(args.want_msg), caught)
+				return err
+			}
+
+			err := test.CHECK.String("file", "errors_test.go", filepath.Base(af.File))
+			if err != nil {
+				return err
+			}
+
+			if !strings.HasSuffix(af.Func, args.want_fn) {
+				err := test.FAIL.String("func", "*"+args.want_fn, af.Func)
+				return err
+			}
+
+			if af.Line <= 0 {
+				err := test.FAIL.String("line", "a positive line", strconv.Itoa(af.Line))
+				return err
+			}
+
+			err = test.CHECK.ErrorMessage("", args.want_msg, af)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Cond", args{
+		fn:       failCond,
+		want_fn:  "go-verify.failCond",
+		want_msg: "[ASSERT FAIL]: foo",
+	})
+
+	_ = tests.Add("NotZero", args{
+		fn:       failNotZero,
+		want_fn:  "go-verify.failNotZero",
+		want_msg: "[ASSERT FAIL]: v is zero",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestErrAssertFailFormat tests the Format method of ErrAssertFail.
+func TestErrAssertFailFormat(t *testing.T) {
+	err := &ErrAssertFail{
+		Msg:  "foo",
+		Func: "main.bar",
+		File: "main.go",
+		Line: 42,
+	}
+
+	got := fmt.Sprintf("%v", err)
+
+	e := test.CHECK.String("%v", "[ASSERT FAIL]: foo", got)
+	if e != nil {
+		t.Error(e)
+	}
+
+	got = fmt.Sprintf("%+v", err)
+
+	e = test.CHECK.String("%+v", "[ASSERT FAIL]: foo\nmain.bar\n\tmain.go:42", got)
+	if e != nil {
+		t.Error(e)
+	}
+}
+
+// TestErrAssertFailLocale tests that the messages follow the current locale.
+func TestErrAssertFailLocale(t *testing.T) {
+	message.Register("zz", message.Catalog{
+		message.AssertFail:   "[ÉCHEC]: {msg}",
+		message.TypeMismatch: "{name} est {got} au lieu de {want}",
+		message.IsNilWant:    "{name} est nil au lieu de {want}",
+		message.Less:         "{name} vaut {got}, pas < {bound}",
+		message.Empty:        "{name} est vide",
+		message.Bug:          "[bogue] {msg}",
+	})
+
+	prev := message.SetLocale("zz")
+	defer message.SetLocale(prev)
+
+	type args struct {
+		fn   func()
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Type", args{
+		fn:   func() { Type[int]("foo", "v", false) },
+		want: "[ÉCHEC]: v est string au lieu de int",
+	})
+
+	_ = tests.Add("Deref", args{
+		fn:   func() { _ = Deref[int](nil, "p") },
+		want: "[ÉCHEC]: p est nil au lieu de int",
+	})
+
+	_ = tests.Add("Less", args{
+		fn:   func() { Less(5, 3, "n") },
+		want: "[ÉCHEC]: n vaut 5, pas < 3",
+	})
+
+	_ = tests.Add("NonEmpty", args{
+		fn:   func() { NonEmpty([]int{}, "list") },
+		want: "[ÉCHEC]: list est vide",
+	})
+
+	_ = tests.Add("FoundBug", args{
+		fn:   func() { FoundBug("foo") },
+		want: "[bogue] foo",
+	})
+
+	_ = tests.Run(t)
+}
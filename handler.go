@@ -0,0 +1,219 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// Handler is a function that receives every failed assertion before any
+// panic happens.
+//
+// Parameters:
+//   - err: The failed assertion. Never nil.
+//
+// Returns:
+//   - bool: True if the assertion should panic with err, false if the
+//     execution should continue.
+type Handler func(err *ErrAssertFail) bool
+
+var (
+	// handler is the process-wide handler. Never nil.
+	handler atomic.Pointer[Handler]
+)
+
+func init() {
+	h := Handler(DefaultHandler)
+	handler.Store(&h)
+}
+
+// DefaultHandler is the handler used when no other handler is set. It
+// behaves according to the current mode:
+//   - ModePanic: Requests a panic.
+//   - ModeLog: Logs the failure to the standard error and continues.
+//   - ModeCount: Continues. The failure is only reflected by FailCount.
+//
+// Custom handlers can delegate to it to keep the behavior of the modes.
+//
+// Parameters:
+//   - err: The failed assertion. Must not be nil.
+//
+// Returns:
+//   - bool: True if the assertion should panic, false otherwise.
+func DefaultHandler(err *ErrAssertFail) bool {
+	switch CurrentMode() {
+	case ModeLog:
+		if err.File == "" {
+			logger.Print(err.Error())
+		} else {
+			logger.Print(err.Error() + " (" + err.File + ":" + strconv.Itoa(err.Line) + ")")
+		}
+
+		return false
+	case ModeCount:
+		return false
+	default:
+		return true
+	}
+}
+
+// SetHandler sets the process-wide handler of failed assertions.
+//
+// Parameters:
+//   - h: The new handler. If nil, DefaultHandler is used.
+//
+// Returns:
+//   - Handler: The previous handler. Never nil.
+//
+// Example:
+//
+//	prev := SetHandler(func(err *ErrAssertFail) bool {
+//		slog.Error("assertion failed", "msg", err.Msg, "file", err.File, "line", err.Line)
+//		return false
+//	})
+//	defer SetHandler(prev)
+func SetHandler(h Handler) Handler {
+	if h == nil {
+		h = DefaultHandler
+	}
+
+	prev := handler.Swap(&h)
+	return *prev
+}
+
+// handlerKey is the context key of the scoped handler.
+type handlerKey struct{}
+
+// WithHandler returns a copy of the context that carries the given handler.
+// The handler is used, instead of the process-wide one, by the assertions
+// that receive the context such as CondContext.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - h: The scoped handler. If nil, the process-wide handler is used.
+//
+// Returns:
+//   - context.Context: The derived context. Never returns nil.
+//
+// Panics:
+//   - "parameter (ctx) must not be nil": If ctx is nil.
+func WithHandler(ctx context.Context, h Handler) context.Context {
+	if ctx == nil {
+		panic("parameter (ctx) must not be nil")
+	}
+
+	ctx = context.WithValue(ctx, handlerKey{}, h)
+	return ctx
+}
+
+// HandlerFromContext returns the handler carried by the context. If the
+// context carries no handler, the process-wide handler is returned.
+//
+// Parameters:
+//   - ctx: The context. If nil, the process-wide handler is returned.
+//
+// Returns:
+//   - Handler: The handler. Never returns nil.
+func HandlerFromContext(ctx context.Context) Handler {
+	if ctx != nil {
+		h, _ := ctx.Value(handlerKey{}).(Handler)
+		if h != nil {
+			return h
+		}
+	}
+
+	h := *handler.Load()
+	return h
+}
+
+// CondContext is like Cond but the failure is sent to the handler carried by
+// the context.
+//
+// Parameters:
+//   - ctx: The context that carries the handler.
+//   - cond: The condition to check.
+//   - msg: The error message to use if the condition is not true.
+//
+// Panics:
+//   - ErrAssertFail: If the condition is not true and the handler requests it.
+func CondContext(ctx context.Context, cond bool, msg string) {
+	if !enabled {
+		return
+	}
+
+	if cond {
+		return
+	}
+
+	err := newErrAssertFail(msg)
+	failWith(HandlerFromContext(ctx), err)
+}
+
+// CondfContext is like Condf but the failure is sent to the handler carried
+// by the context.
+//
+// Parameters:
+//   - ctx: The context that carries the handler.
+//   - cond: The condition to check.
+//   - format: The format string for the error message to use if the condition is not true.
+//   - args: The arguments to use with the format string.
+//
+// Panics:
+//   - ErrAssertFail: If the condition is not true and the handler requests it.
+func CondfContext(ctx context.Context, cond bool, format string, args ...any) {
+	if !enabled {
+		return
+	}
+
+	if cond {
+		return
+	}
+
+	err := newErrAssertFail(fmt.Sprintf(format, args...))
+	failWith(HandlerFromContext(ctx), err)
+}
+
+// fail sends a failed assertion to the process-wide handler.
+//
+// Parameters:
+//   - err: The failure. Must not be nil.
+//
+// Panics:
+//   - *ErrAssertFail: If the handler requests it.
+func fail(err *ErrAssertFail) {
+	h := *handler.Load()
+	failWith(h, err)
+}
+
+// failWith sends a failed assertion to the given handler.
+//
+// Parameters:
+//   - h: The handler. Must not be nil.
+//   - err: The failure. Must not be nil.
+//
+// Panics:
+//   - *ErrAssertFail: If the handler requests it.
+func failWith(h Handler, err *ErrAssertFail) {
+	raise(h, err, err)
+}
+
+// raise sends a failed assertion to the given handler and, if the handler
+// requests it, panics with the given value instead of the failure itself.
+//
+// Parameters:
+//   - h: The handler. Must not be nil.
+//   - err: The failure. Must not be nil.
+//   - v: The value to panic with. It should wrap err, or be the inner error
+//     of err.
+//
+// Panics:
+//   - v: If the handler requests it.
+func raise(h Handler, err *ErrAssertFail, v error) {
+	fail_count.Add(1)
+
+	ok := h(err)
+	if ok {
+		panic(v)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"context"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestSetHandler tests that the assertions send their failures to the
+// handler.
+func TestSetHandler(t *testing.T) {
+	type args struct {
+		panics   bool
+		want_err string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			var got []string
+
+			prev := SetHandler(func(err *ErrAssertFail) bool {
+				got = append(got, err.Msg)
+				return args.panics
+			})
+			defer SetHandler(prev)
+
+			caught := test.Try(func() {
+				Cond(false, "foo")
+				NotZero(0, "v")
+			})
+
+			err := test.CHECK.ErrorMessage("", args.want_err, caught)
+			if err != nil {
+				return err
+			}
+
+			err = test.CHECK.String("first failure", "foo", got[0])
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("handler panics", args{
+		panics:   true,
+		want_err: NewErrAssertFail("foo").Error(),
+	})
+
+	_ = tests.Add("handler continues", args{
+		panics:   false,
+		want_err: "",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestCondContext tests that CondContext uses the handler of the context.
+func TestCondContext(t *testing.T) {
+	var count int
+
+	ctx := WithHandler(context.Background(), func(err *ErrAssertFail) bool {
+		count++
+		return false
+	})
+
+	caught := test.Try(func() {
+		CondContext(ctx, false, "foo")
+		CondfContext(ctx, false, "%s", "bar")
+		CondContext(ctx, true, "baz")
+	})
+
+	err := test.CHECK.ErrorMessage("", "", caught)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = test.CHECK.Int("handled failures", 2, count)
+	if err != nil {
+		t.Error(err)
+	}
+
+	caught = test.Try(func() {
+		CondContext(context.Background(), false, "foo")
+	})
+
+	err = test.CHECK.ErrorMessage("", NewErrAssertFail("foo").Error(), caught)
+	if err != nil {
+		t.Error(err)
+	}
+}
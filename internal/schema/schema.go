@@ -0,0 +1,37 @@
+// Package schema holds the version of the JSON schema shared by the errors of
+// the assert and test packages.
+package schema
+
+import (
+	"fmt"
+)
+
+const (
+	// Version is the version of the JSON schema of the errors. It is only
+	// incremented when a change would break existing parsers; new optional
+	// fields do not change it.
+	Version int = 1
+)
+
+// Check checks the schema version and the type of a decoded document.
+//
+// Parameters:
+//   - schema: The decoded schema version.
+//   - typ: The decoded type. Accepted if empty.
+//   - want: The expected type.
+//
+// Returns:
+//   - error: An error if the document is not supported.
+func Check(schema int, typ, want string) error {
+	if schema > Version {
+		err := fmt.Errorf("schema version %d is not supported, want at most %d", schema, Version)
+		return err
+	}
+
+	if typ != "" && typ != want {
+		err := fmt.Errorf("type = %q, want %q", typ, want)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package assert
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
+// Invariant is a condition that must always hold for the values of a type.
+// Unlike Validater, which checks user input, an invariant that does not hold
+// is always a bug.
+//
+// Parameters:
+//   - v: The value to check.
+//
+// Returns:
+//   - error: An error describing the violation. Nil if the invariant holds.
+type Invariant[T any] func(v T) error
+
+var (
+	// invariants is the registry of invariants, indexed by type.
+	invariants map[reflect.Type][]func(v any) error = make(map[reflect.Type][]func(v any) error)
+
+	// invariants_mu protects invariants.
+	invariants_mu sync.RWMutex
+
+	// noop is the function returned by Guard when assertions are disabled.
+	noop func() = func() {}
+)
+
+// RegisterInvariant registers an invariant for the type T. Every invariant
+// registered for a type is run by CheckInvariants and Guard. T should be a
+// concrete type since the lookup is done on the dynamic type of the values.
+//
+// Parameters:
+//   - fn: The invariant.
+//
+// Panics:
+//   - "parameter (fn) must not be nil": If fn is nil.
+//
+// Example:
+//
+//	func init() {
+//		RegisterInvariant(func(s *Stack) error {
+//			if s.top > len(s.elems) {
+//				return errors.New("top is out of bounds")
+//			}
+//
+//			return nil
+//		})
+//	}
+func RegisterInvariant[T any](fn Invariant[T]) {
+	if fn == nil {
+		panic("parameter (fn) must not be nil")
+	}
+
+	typ := reflect.TypeFor[T]()
+
+	wrapped := func(v any) error {
+		err := fn(v.(T))
+		return err
+	}
+
+	invariants_mu.Lock()
+	defer invariants_mu.Unlock()
+
+	invariants[typ] = append(invariants[typ], wrapped)
+}
+
+// CheckInvariants asserts whether every invariant registered for the dynamic
+// type of v holds. If v is a non-nil pointer, the invariants registered for
+// the pointed type are checked as well. If not, it panics with an
+// ErrAssertFail error that wraps every violation.
+//
+// Parameters:
+//   - v: The value to check. Does nothing if nil, including typed nil
+//     pointers.
+//
+// Example:
+//
+//	CheckInvariants(s) // Panics: invariant of *Stack = top is out of bounds
+func CheckInvariants(v any) {
+	if !enabled {
+		return
+	}
+
+	checkInvariants(v, message.Invariant)
+}
+
+// Guard checks the invariants of the value pointed by v on method entry and
+// returns a function that checks them again on exit. It is meant to be
+// deferred. Under the "verify_noassert" build tag, it does nothing.
+//
+// If the method panics, the exit check is skipped and the panic goes on
+// unchanged. The returned function must therefore be deferred directly, as
+// in the example.
+//
+// Parameters:
+//   - v: The pointer to the value to guard. Does nothing if nil. If the value
+//     it points to is nil, as for a nil receiver, its invariants are not
+//     checked.
+//
+// Returns:
+//   - func(): The function that checks the invariants on exit. Never returns
+//     nil.
+//
+// Example:
+//
+//	func (s *Stack) Push(e int) {
+//		defer assert.Guard(&s)()
+//
+//		// ...
+//	}
+func Guard[T any](v *T) func() {
+	if !enabled {
+		return noop
+	}
+
+	if v == nil {
+		return noop
+	}
+
+	checkInvariants(*v, message.InvariantEntry)
+
+	fn := func() {
+		r := recover()
+		if r != nil {
+			// The method is panicking: its invariants may well be broken,
+			// and reporting them would hide the cause of the panic.
+			panic(r)
+		}
+
+		checkInvariants(*v, message.InvariantExit)
+	}
+
+	return fn
+}
+
+// lookupInvariants returns the invariants registered for the given type.
+//
+// Parameters:
+//   - typ: The type.
+//
+// Returns:
+//   - []func(v any) error: The invariants.
+func lookupInvariants(typ reflect.Type) []func(v any) error {
+	invariants_mu.RLock()
+	defer invariants_mu.RUnlock()
+
+	fns := invariants[typ]
+	return fns
+}
+
+// checkInvariants runs the invariants of v and fails if any is violated.
+//
+// Parameters:
+//   - v: The value to check. Does nothing if nil, including typed nil
+//     pointers, since an invariant may not expect a nil receiver.
+//   - key: The key of the message, which tells when the check happened.
+func checkInvariants(v any, key message.Key) {
+	if isNil(v) {
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	typ := rv.Type()
+
+	var errs []error
+
+	for _, fn := range lookupInvariants(typ) {
+		err := fn(v)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if typ.Kind() == reflect.Pointer && rv.Elem().CanInterface() {
+		elem := rv.Elem().Interface()
+
+		for _, fn := range lookupInvariants(typ.Elem()) {
+			err := fn(elem)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return
+	}
+
+	msgs := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	msg := message.Format(key, "type", typ.String(), "msgs", strings.Join(msgs, "; "))
+
+	err := newErrAssertFail(msg)
+	err.Inner = errors.Join(errs...)
+
+	fail(err)
+}
@@ -0,0 +1,140 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// mockStack is a mock data structure with invariants.
+type mockStack struct {
+	// elems is the elements of the stack.
+	elems []int
+
+	// top is the number of elements in the stack.
+	top int
+}
+
+// push pushes an element. If broken is true, the invariant is broken.
+func (s *mockStack) push(e int, broken bool) {
+	defer Guard(&s)()
+
+	s.elems = append(s.elems, e)
+
+	if !broken {
+		s.top++
+	}
+}
+
+// pushFail breaks the invariant and panics before restoring it.
+func (s *mockStack) pushFail(e int) {
+	defer Guard(&s)()
+
+	s.elems = append(s.elems, e)
+
+	panic("out of memory")
+}
+
+// size returns the number of elements. It accepts a nil receiver.
+func (s *mockStack) size() int {
+	defer Guard(&s)()
+
+	if s == nil {
+		return 0
+	}
+
+	return s.top
+}
+
+func init() {
+	RegisterInvariant(func(s *mockStack) error {
+		if s.top != len(s.elems) {
+			return errors.New("top != len(elems)")
+		}
+
+		return nil
+	})
+}
+
+// TestGuard tests the Guard and CheckInvariants functions.
+func TestGuard(t *testing.T) {
+	type args struct {
+		fn   func()
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("invariant holds", args{
+		fn: func() {
+			s := &mockStack{}
+			s.push(1, false)
+			CheckInvariants(s)
+		},
+		want: "",
+	})
+
+	_ = tests.Add("broken on exit", args{
+		fn: func() {
+			s := &mockStack{}
+			s.push(1, true)
+		},
+		want: NewErrAssertFail("invariant of *assert.mockStack = top != len(elems) on exit").Error(),
+	})
+
+	_ = tests.Add("broken on entry", args{
+		fn: func() {
+			s := &mockStack{top: 1}
+			s.push(1, false)
+		},
+		want: NewErrAssertFail("invariant of *assert.mockStack = top != len(elems) on entry").Error(),
+	})
+
+	_ = tests.Add("panic is not hidden", args{
+		fn: func() {
+			s := &mockStack{}
+			s.pushFail(1)
+		},
+		want: "out of memory",
+	})
+
+	_ = tests.Add("CheckInvariants", args{
+		fn: func() {
+			CheckInvariants(&mockStack{top: 2})
+		},
+		want: NewErrAssertFail("invariant of *assert.mockStack = top != len(elems)").Error(),
+	})
+
+	_ = tests.Add("nil receiver", args{
+		fn: func() {
+			var s *mockStack
+
+			_ = s.size()
+			CheckInvariants(s)
+		},
+		want: "",
+	})
+
+	_ = tests.Add("no invariant registered", args{
+		fn: func() {
+			CheckInvariants(42)
+		},
+		want: "",
+	})
+
+	_ = tests.Run(t)
+}
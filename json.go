@@ -0,0 +1,509 @@
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/PlayerR9/go-verify/internal/schema"
+)
+
+const (
+	// SchemaVersion is the version of the JSON schema of the errors of this
+	// package. It is shared with the errors of the test package.
+	SchemaVersion int = schema.Version
+)
+
+// errAssertFailJSON is the JSON form of ErrAssertFail.
+//
+// Format:
+//
+//	{
+//		"schema": 1,
+//		"type": "assert_fail",
+//		"msg": "v is zero",
+//		"kind": "zero",
+//		"inner": "...",
+//		"file": "/path/to/file.go",
+//		"line": 42,
+//		"func": "pkg.Func",
+//		"stack": [{"func": "pkg.Func", "file": "/path/to/file.go", "line": 42}]
+//	}
+type errAssertFailJSON struct {
+	Schema int     `json:"schema"`
+	Type   string  `json:"type"`
+	Msg    string  `json:"msg"`
+	Kind   string  `json:"kind"`
+	Inner  string  `json:"inner,omitempty"`
+	File   string  `json:"file,omitempty"`
+	Line   int     `json:"line,omitempty"`
+	Func   string  `json:"func,omitempty"`
+	Stack  []Frame `json:"stack,omitempty"`
+}
+
+const (
+	// assertFailType is the value of the "type" field of an ErrAssertFail.
+	assertFailType string = "assert_fail"
+)
+
+// MarshalJSON implements json.Marshaler.
+//
+// The inner error is encoded as its error message, under the "inner" field.
+// The document always carries the "schema" field, set to SchemaVersion.
+func (e ErrAssertFail) MarshalJSON() ([]byte, error) {
+	data := errAssertFailJSON{
+		Schema: SchemaVersion,
+		Type:   assertFailType,
+		Msg:    e.Msg,
+		Kind:   e.Kind.String(),
+		File:   e.File,
+		Line:   e.Line,
+		Func:   e.Func,
+		Stack:  e.Stack,
+	}
+
+	if e.Inner != nil {
+		data.Inner = e.Inner.Error()
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Since only the message of the inner error is encoded, the decoded inner
+// error is a plain error with that message.
+//
+// Errors:
+//   - error: If the data is not valid JSON, if the schema version is newer
+//     than SchemaVersion, or if the type or the kind is unknown.
+func (e *ErrAssertFail) UnmarshalJSON(b []byte) error {
+	var data errAssertFailJSON
+
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+
+	err = schema.Check(data.Schema, data.Type, assertFailType)
+	if err != nil {
+		return err
+	}
+
+	kind := KindCondition
+
+	if data.Kind != "" {
+		var ok bool
+
+		kind, ok = parseKind(data.Kind)
+		if !ok {
+			err := fmt.Errorf("kind %q is not supported", data.Kind)
+			return err
+		}
+	}
+
+	*e = ErrAssertFail{
+		Msg:   data.Msg,
+		Kind:  kind,
+		File:  data.File,
+		Line:  data.Line,
+		Func:  data.Func,
+		Stack: data.Stack,
+	}
+
+	if data.Inner != "" {
+		e.Inner = errors.New(data.Inner)
+	}
+
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The failure is logged as a group with the "msg" and "kind" attributes and,
+// when known, the "inner", "file", "line" and "func" attributes. The stack
+// trace is not logged.
+func (e ErrAssertFail) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", e.Msg),
+		slog.String("kind", e.Kind.String()),
+	}
+
+	if e.Inner != nil {
+		attrs = append(attrs, slog.String("inner", e.Inner.Error()))
+	}
+
+	if e.File != "" {
+		attrs = append(attrs,
+			slog.String("file", e.File),
+			slog.Int("line", e.Line),
+		)
+	}
+
+	if e.Func != "" {
+		attrs = append(attrs, slog.String("func", e.Func))
+	}
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
+
+const (
+	// panicType is the value of the "type" field of an ErrPanic.
+	panicType string = "panic"
+
+	// bugType is the value of the "type" field of an ErrBug.
+	bugType string = "bug"
+
+	// preconditionType is the value of the "type" field of an
+	// ErrPrecondition.
+	preconditionType string = "precondition"
+
+	// postconditionType is the value of the "type" field of an
+	// ErrPostcondition.
+	postconditionType string = "postcondition"
+
+	// softFailType is the value of the "type" field of an ErrSoftFail.
+	softFailType string = "soft_fail"
+)
+
+// errPanicJSON is the JSON form of ErrPanic. It is the same as the one of the
+// ErrPanic of the test package.
+//
+// Format:
+//
+//	{
+//		"schema": 1,
+//		"type": "panic",
+//		"value": "42",
+//		"value_type": "int",
+//		"stack": "main.main\n\t/path/to/file.go:42",
+//		"runtime": false,
+//		"nil_panic": false
+//	}
+type errPanicJSON struct {
+	Schema    int    `json:"schema"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	ValueType string `json:"value_type"`
+	Stack     string `json:"stack,omitempty"`
+	Runtime   bool   `json:"runtime,omitempty"`
+	NilPanic  bool   `json:"nil_panic,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Since the value of the panic can be of any type, such as a function or a
+// channel, it is encoded as its %v representation, and its type as its %T
+// representation. The document always carries the "schema" field, set to
+// SchemaVersion.
+func (e ErrPanic) MarshalJSON() ([]byte, error) {
+	data := errPanicJSON{
+		Schema:    SchemaVersion,
+		Type:      panicType,
+		Value:     fmt.Sprintf("%v", e.Value),
+		ValueType: fmt.Sprintf("%T", e.Value),
+		Stack:     e.Stack,
+		Runtime:   e.Runtime,
+		NilPanic:  e.NilPanic,
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The panic is logged as a group with the "value" and "value_type"
+// attributes and, when set, the "runtime" and "nil_panic" flags. The value is
+// logged as its %v representation. The stack trace is not logged.
+func (e ErrPanic) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("value", fmt.Sprintf("%v", e.Value)),
+		slog.String("value_type", fmt.Sprintf("%T", e.Value)),
+	}
+
+	if e.Runtime {
+		attrs = append(attrs, slog.Bool("runtime", true))
+	}
+
+	if e.NilPanic {
+		attrs = append(attrs, slog.Bool("nil_panic", true))
+	}
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
+
+// fieldJSON is the JSON form of a Field.
+//
+// Format:
+//
+//	{"key": "n", "value": "3"}
+type fieldJSON struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// errBugJSON is the JSON form of ErrBug.
+//
+// Format:
+//
+//	{
+//		"schema": 1,
+//		"type": "bug",
+//		"msg": "index out of sync",
+//		"context": [{"key": "n", "value": "3"}],
+//		"file": "/path/to/file.go",
+//		"line": 42,
+//		"func": "pkg.Func"
+//	}
+type errBugJSON struct {
+	Schema  int         `json:"schema"`
+	Type    string      `json:"type"`
+	Msg     string      `json:"msg"`
+	Context []fieldJSON `json:"context,omitempty"`
+	File    string      `json:"file,omitempty"`
+	Line    int         `json:"line,omitempty"`
+	Func    string      `json:"func,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The context is encoded in order, with each value as its %v
+// representation. The location is the one of the underlying failure. The
+// document always carries the "schema" field, set to SchemaVersion.
+func (e ErrBug) MarshalJSON() ([]byte, error) {
+	data := errBugJSON{
+		Schema: SchemaVersion,
+		Type:   bugType,
+		Msg:    e.Msg,
+	}
+
+	for _, field := range e.Context {
+		data.Context = append(data.Context, fieldJSON{
+			Key:   field.Key,
+			Value: fmt.Sprint(field.Value),
+		})
+	}
+
+	if e.Fail != nil {
+		data.File = e.Fail.File
+		data.Line = e.Fail.Line
+		data.Func = e.Fail.Func
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The bug is logged as a group with the "msg" attribute, the "context" group,
+// if any, and the location of the underlying failure, when known.
+func (e ErrBug) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", e.Msg),
+	}
+
+	if len(e.Context) > 0 {
+		fields := make([]any, 0, len(e.Context))
+
+		for _, field := range e.Context {
+			fields = append(fields, slog.Any(field.Key, field.Value))
+		}
+
+		attrs = append(attrs, slog.Group("context", fields...))
+	}
+
+	attrs = appendLocation(attrs, e.Fail)
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
+
+// errContractJSON is the JSON form of ErrPrecondition and ErrPostcondition.
+//
+// Format:
+//
+//	{
+//		"schema": 1,
+//		"type": "precondition",
+//		"msg": "n > 0",
+//		"file": "/path/to/file.go",
+//		"line": 42,
+//		"func": "pkg.Func"
+//	}
+type errContractJSON struct {
+	Schema int    `json:"schema"`
+	Type   string `json:"type"`
+	Msg    string `json:"msg"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Func   string `json:"func,omitempty"`
+}
+
+// marshalContract encodes a contract failure.
+//
+// Parameters:
+//   - typ: The value of the "type" field.
+//   - fail: The underlying failure. May be nil.
+//
+// Returns:
+//   - []byte: The JSON document.
+//   - error: An error if the encoding failed.
+func marshalContract(typ string, fail *ErrAssertFail) ([]byte, error) {
+	data := errContractJSON{
+		Schema: SchemaVersion,
+		Type:   typ,
+	}
+
+	if fail != nil {
+		data.Msg = fail.Msg
+		data.File = fail.File
+		data.Line = fail.Line
+		data.Func = fail.Func
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// logContract returns the log value of a contract failure.
+//
+// Parameters:
+//   - fail: The underlying failure. May be nil.
+//
+// Returns:
+//   - slog.Value: The group with the "msg" attribute and the location of the
+//     failure, when known.
+func logContract(fail *ErrAssertFail) slog.Value {
+	var attrs []slog.Attr
+
+	if fail != nil {
+		attrs = append(attrs, slog.String("msg", fail.Msg))
+	}
+
+	attrs = appendLocation(attrs, fail)
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The message and the location are the ones of the underlying failure. The
+// document always carries the "schema" field, set to SchemaVersion.
+func (e ErrPrecondition) MarshalJSON() ([]byte, error) {
+	b, err := marshalContract(preconditionType, e.Fail)
+	return b, err
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The precondition is logged as a group with the "msg" attribute and the
+// location of the underlying failure, when known.
+func (e ErrPrecondition) LogValue() slog.Value {
+	v := logContract(e.Fail)
+	return v
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The message and the location are the ones of the underlying failure. The
+// document always carries the "schema" field, set to SchemaVersion.
+func (e ErrPostcondition) MarshalJSON() ([]byte, error) {
+	b, err := marshalContract(postconditionType, e.Fail)
+	return b, err
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The postcondition is logged as a group with the "msg" attribute and the
+// location of the underlying failure, when known.
+func (e ErrPostcondition) LogValue() slog.Value {
+	v := logContract(e.Fail)
+	return v
+}
+
+// errSoftFailJSON is the JSON form of ErrSoftFail.
+//
+// Format:
+//
+//	{
+//		"schema": 1,
+//		"type": "soft_fail",
+//		"failures": [{"schema": 1, "type": "assert_fail", "msg": "foo", ...}]
+//	}
+type errSoftFailJSON struct {
+	Schema   int              `json:"schema"`
+	Type     string           `json:"type"`
+	Failures []*ErrAssertFail `json:"failures"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Each failure is encoded as an ErrAssertFail document. The document always
+// carries the "schema" field, set to SchemaVersion.
+func (e ErrSoftFail) MarshalJSON() ([]byte, error) {
+	data := errSoftFailJSON{
+		Schema:   SchemaVersion,
+		Type:     softFailType,
+		Failures: e.Failures,
+	}
+
+	if data.Failures == nil {
+		data.Failures = []*ErrAssertFail{}
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The failures are logged as a group with the "count" attribute and one
+// group per failure, keyed by its index.
+func (e ErrSoftFail) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(e.Failures)+1)
+
+	attrs = append(attrs, slog.Int("count", len(e.Failures)))
+
+	for i, failure := range e.Failures {
+		attrs = append(attrs, slog.Any(strconv.Itoa(i), failure))
+	}
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
+
+// appendLocation appends the location of a failure to the attributes.
+//
+// Parameters:
+//   - attrs: The attributes.
+//   - fail: The failure. May be nil.
+//
+// Returns:
+//   - []slog.Attr: The attributes with the "file", "line" and "func"
+//     attributes, when known.
+func appendLocation(attrs []slog.Attr, fail *ErrAssertFail) []slog.Attr {
+	if fail == nil {
+		return attrs
+	}
+
+	if fail.File != "" {
+		attrs = append(attrs,
+			slog.String("file", fail.File),
+			slog.Int("line", fail.Line),
+		)
+	}
+
+	if fail.Func != "" {
+		attrs = append(attrs, slog.String("func", fail.Func))
+	}
+
+	return attrs
+}
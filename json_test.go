@@ -0,0 +1,279 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestErrAssertFailJSON tests the round trip of ErrAssertFail through JSON.
+func TestErrAssertFailJSON(t *testing.T) {
+	want := &ErrAssertFail{
+		Msg:   "v = nil",
+		Kind:  KindNil,
+		Inner: errors.New("foo"),
+		File:  "main.go",
+		Line:  42,
+		Func:  "main.main",
+		Stack: []Frame{{Func: "main.main", File: "main.go", Line: 42}},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	str := string(b)
+	if !strings.Contains(str, `"schema":1`) || !strings.Contains(str, `"kind":"nil"`) {
+		t.Errorf("want the schema and the kind to be encoded, got %s", str)
+	}
+
+	var got ErrAssertFail
+
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	err = test.CHECK.String("message", want.Error(), got.Error())
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = test.CHECK.String("kind", want.Kind.String(), got.Kind.String())
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = test.CHECK.ErrorMessage("inner", "foo", got.Inner)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if got.File != want.File || got.Line != want.Line || len(got.Stack) != 1 {
+		t.Errorf("want the location to be kept, got %s:%d", got.File, got.Line)
+	}
+}
+
+// TestErrAssertFailUnmarshalJSON tests that unsupported documents are
+// rejected.
+func TestErrAssertFailUnmarshalJSON(t *testing.T) {
+	type args struct {
+		data     string
+		want_err string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			var e ErrAssertFail
+
+			err := json.Unmarshal([]byte(args.data), &e)
+
+			err = test.CHECK.ErrorMessage("", args.want_err, err)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("valid", args{
+		data:     `{"schema":1,"type":"assert_fail","msg":"foo","kind":"bug"}`,
+		want_err: "",
+	})
+
+	_ = tests.Add("newer schema", args{
+		data:     `{"schema":2,"type":"assert_fail","msg":"foo"}`,
+		want_err: "schema version 2 is not supported, want at most 1",
+	})
+
+	_ = tests.Add("other type", args{
+		data:     `{"schema":1,"type":"panic","msg":"foo"}`,
+		want_err: `type = "panic", want "assert_fail"`,
+	})
+
+	_ = tests.Add("unknown kind", args{
+		data:     `{"schema":1,"type":"assert_fail","kind":"foo"}`,
+		want_err: `kind "foo" is not supported`,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestErrAssertFailLogValue tests that ErrAssertFail is logged as fields.
+func TestErrAssertFailLogValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	var err error = &ErrAssertFail{
+		Msg:  "v is zero",
+		Kind: KindZero,
+		File: "main.go",
+		Line: 42,
+	}
+
+	logger.Error("assertion failed", "err", err)
+
+	want := `"err":{"msg":"v is zero","kind":"zero","file":"main.go","line":42}`
+
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("want %s to be logged, got %s", want, buf.String())
+	}
+}
+
+// TestErrorsJSON tests the JSON form of the other errors of the package.
+func TestErrorsJSON(t *testing.T) {
+	type args struct {
+		err error
+
+		// want is a part of the wanted document.
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			b, err := json.Marshal(args.err)
+			if err != nil {
+				return err
+			}
+
+			str := string(b)
+
+			if !strings.HasPrefix(str, `{"schema":1,`) || !strings.Contains(str, args.want) {
+				err := test.FAIL.String("json", "*"+args.want+"*", str)
+				return err
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	fail := &ErrAssertFail{
+		Msg:  "n is positive",
+		File: "main.go",
+		Line: 42,
+		Func: "main.main",
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("panic with a function", args{
+		err:  &ErrPanic{Value: func() {}},
+		want: `"type":"panic","value":"0x`,
+	})
+
+	_ = tests.Add("panic with a channel", args{
+		err: &ErrPanic{
+			Value:   make(chan int),
+			Stack:   "main.main\n\tmain.go:42",
+			Runtime: true,
+		},
+		want: `"value_type":"chan int","stack":"main.main\n\tmain.go:42","runtime":true}`,
+	})
+
+	_ = tests.Add("bug", args{
+		err: &ErrBug{
+			Msg:     "out of sync",
+			Context: []Field{{Key: "n", Value: 3}, {Key: "f", Value: func() {}}},
+			Fail:    fail,
+		},
+		want: `"type":"bug","msg":"out of sync","context":[{"key":"n","value":"3"},{"key":"f","value":"0x`,
+	})
+
+	_ = tests.Add("precondition", args{
+		err:  &ErrPrecondition{Fail: fail},
+		want: `{"schema":1,"type":"precondition","msg":"n is positive","file":"main.go","line":42,"func":"main.main"}`,
+	})
+
+	_ = tests.Add("postcondition", args{
+		err:  &ErrPostcondition{Fail: fail},
+		want: `{"schema":1,"type":"postcondition","msg":"n is positive","file":"main.go","line":42,"func":"main.main"}`,
+	})
+
+	_ = tests.Add("soft failure", args{
+		err:  &ErrSoftFail{Failures: []*ErrAssertFail{{Msg: "foo"}}},
+		want: `{"schema":1,"type":"soft_fail","failures":[{"schema":1,"type":"assert_fail","msg":"foo","kind":"condition"}]}`,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestErrorsLogValue tests that the other errors of the package are logged
+// as fields.
+func TestErrorsLogValue(t *testing.T) {
+	type args struct {
+		err  error
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			var buf bytes.Buffer
+
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			logger.Error("failed", "err", args.err)
+
+			if !strings.Contains(buf.String(), args.want) {
+				err := test.FAIL.String("log", "*"+args.want+"*", buf.String())
+				return err
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	fail := &ErrAssertFail{
+		Msg:  "n > 0",
+		File: "main.go",
+		Line: 42,
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("panic", args{
+		err:  &ErrPanic{Value: make(chan int), NilPanic: true},
+		want: `"err":{"value":"0x`,
+	})
+
+	_ = tests.Add("panic type", args{
+		err:  &ErrPanic{Value: 42, Runtime: true},
+		want: `"err":{"value":"42","value_type":"int","runtime":true}`,
+	})
+
+	_ = tests.Add("bug", args{
+		err: &ErrBug{
+			Msg:     "out of sync",
+			Context: []Field{{Key: "n", Value: 3}},
+			Fail:    fail,
+		},
+		want: `"err":{"msg":"out of sync","context":{"n":3},"file":"main.go","line":42}`,
+	})
+
+	_ = tests.Add("precondition", args{
+		err:  &ErrPrecondition{Fail: fail},
+		want: `"err":{"msg":"n > 0","file":"main.go","line":42}`,
+	})
+
+	_ = tests.Add("postcondition", args{
+		err:  &ErrPostcondition{Fail: fail},
+		want: `"err":{"msg":"n > 0","file":"main.go","line":42}`,
+	})
+
+	_ = tests.Add("soft failure", args{
+		err:  &ErrSoftFail{Failures: []*ErrAssertFail{{Msg: "foo"}}},
+		want: `"err":{"count":1,"0":{"msg":"foo","kind":"condition"}}`,
+	})
+
+	_ = tests.Run(t)
+}
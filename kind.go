@@ -0,0 +1,95 @@
+package assert
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Kind is the category of an assertion failure. It allows recovery code to
+// tell an internal bug apart from bad input that reached an assertion.
+type Kind int
+
+const (
+	// KindCondition is a general condition that was not met. This is the kind
+	// of the failures that have no more specific kind.
+	KindCondition Kind = iota
+
+	// KindNil is a value that was nil.
+	KindNil
+
+	// KindZero is a value that was its zero value.
+	KindZero
+
+	// KindType is a value that was not of the wanted type.
+	KindType
+
+	// KindBug is a bug found by FoundBug.
+	KindBug
+
+	// KindUnreachable is code that was reached but should not have been.
+	KindUnreachable
+)
+
+// String implements fmt.Stringer.
+func (k Kind) String() string {
+	switch k {
+	case KindCondition:
+		return "condition"
+	case KindNil:
+		return "nil"
+	case KindZero:
+		return "zero"
+	case KindType:
+		return "type"
+	case KindBug:
+		return "bug"
+	case KindUnreachable:
+		return "unreachable"
+	default:
+		return "kind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// parseKind parses the name of a kind, as returned by Kind.String.
+//
+// Parameters:
+//   - str: The name of the kind.
+//
+// Returns:
+//   - Kind: The parsed kind.
+//   - bool: True if the name is valid, false otherwise.
+func parseKind(str string) (Kind, bool) {
+	for k := KindCondition; k <= KindUnreachable; k++ {
+		if k.String() == str {
+			return k, true
+		}
+	}
+
+	return KindCondition, false
+}
+
+// KindOf returns the kind of the first assertion failure in the chain of err.
+//
+// Parameters:
+//   - err: The error to inspect.
+//
+// Returns:
+//   - Kind: The kind of the failure. KindCondition if there is none.
+//   - bool: True if err wraps an ErrAssertFail, false otherwise.
+//
+// Example:
+//
+//	kind, ok := KindOf(err)
+//	if ok && kind == KindBug {
+//		report(err)
+//	}
+func KindOf(err error) (Kind, bool) {
+	var af *ErrAssertFail
+
+	ok := errors.As(err, &af)
+	if !ok {
+		return KindCondition, false
+	}
+
+	return af.Kind, true
+}
@@ -0,0 +1,19 @@
+// Package message contains the templates of the failure messages of the
+// assert and test packages.
+//
+// Every message is identified by a Key and written as a template in which
+// the placeholders, such as "{name}", are replaced by named values. The
+// templates are grouped by locale in catalogs. The default English catalog
+// is always registered under DefaultLocale and is used as a fallback for the
+// keys that another locale does not translate.
+//
+//	message.Register("fr", message.Catalog{
+//		message.AssertFail: "[ÉCHEC]: {msg}",
+//		message.IsNil:      "{name} est nil",
+//	})
+//
+//	prev := message.SetLocale("fr")
+//	defer message.SetLocale(prev)
+//
+// Registering a catalog under DefaultLocale changes the English wording.
+package message
@@ -0,0 +1,532 @@
+package message
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultLocale is the locale of the default English catalog.
+	DefaultLocale string = "en"
+)
+
+// Key identifies a message.
+type Key string
+
+const (
+	// AssertFail is the message of a failed assertion.
+	//
+	// Placeholders: {msg}.
+	AssertFail Key = "assert.fail"
+
+	// AssertDefault is the description of a failed assertion that has none.
+	AssertDefault Key = "assert.default"
+
+	// Precondition is the message of a precondition that is not met.
+	//
+	// Placeholders: {msg}.
+	Precondition Key = "assert.precondition"
+
+	// PreconditionDefault is the description of a precondition that has
+	// none.
+	PreconditionDefault Key = "assert.precondition.default"
+
+	// Postcondition is the message of a postcondition that is not met.
+	//
+	// Placeholders: {msg}.
+	Postcondition Key = "assert.postcondition"
+
+	// PostconditionDefault is the description of a postcondition that has
+	// none.
+	PostconditionDefault Key = "assert.postcondition.default"
+
+	// IsNil is the description of a value that is nil.
+	//
+	// Placeholders: {name}.
+	IsNil Key = "assert.nil"
+
+	// IsZero is the description of a value that is its zero value.
+	//
+	// Placeholders: {name}.
+	IsZero Key = "assert.zero"
+
+	// TypeMismatch is the description of a value that is not of the wanted
+	// type.
+	//
+	// Placeholders: {name}, {got}, {want}.
+	TypeMismatch Key = "assert.type"
+
+	// IsNilWant is the description of a pointer that is nil although a value
+	// was wanted.
+	//
+	// Placeholders: {name}, {want}.
+	IsNilWant Key = "assert.nil.want"
+
+	// IsTypedNil is the description of a value that is a typed nil.
+	//
+	// Placeholders: {name}, {type}.
+	IsTypedNil Key = "assert.nil.typed"
+
+	// IsFalse is the description of a condition that is false.
+	//
+	// Placeholders: {name}.
+	IsFalse Key = "assert.false"
+
+	// IsTrue is the description of a condition that is true.
+	//
+	// Placeholders: {name}.
+	IsTrue Key = "assert.true"
+
+	// IsErr is the description of a call that returned an error.
+	//
+	// Placeholders: {name}, {err}.
+	IsErr Key = "assert.err"
+
+	// Equal is the description of a value that is not the wanted one.
+	//
+	// Placeholders: {name}, {got}, {want}.
+	Equal Key = "assert.equal"
+
+	// NotEqual is the description of a value that is the unwanted one.
+	//
+	// Placeholders: {name}, {got}, {want}.
+	NotEqual Key = "assert.notequal"
+
+	// Less is the description of a value that is not less than its bound.
+	//
+	// Placeholders: {name}, {got}, {bound}.
+	Less Key = "assert.less"
+
+	// LessOrEqual is the description of a value that is greater than its
+	// bound.
+	//
+	// Placeholders: {name}, {got}, {bound}.
+	LessOrEqual Key = "assert.lessorequal"
+
+	// Greater is the description of a value that is not greater than its
+	// bound.
+	//
+	// Placeholders: {name}, {got}, {bound}.
+	Greater Key = "assert.greater"
+
+	// InRange is the description of a value that is out of a half-open
+	// range.
+	//
+	// Placeholders: {name}, {got}, {low}, {high}.
+	InRange Key = "assert.inrange"
+
+	// Between is the description of a value that is out of a closed range.
+	//
+	// Placeholders: {name}, {got}, {low}, {high}.
+	Between Key = "assert.between"
+
+	// Len is the description of a slice that does not have the wanted
+	// length.
+	//
+	// Placeholders: {name}, {got}, {want}.
+	Len Key = "assert.len"
+
+	// Empty is the description of a slice that is empty.
+	//
+	// Placeholders: {name}.
+	Empty Key = "assert.empty"
+
+	// Contains is the description of a slice that does not contain the
+	// wanted element.
+	//
+	// Placeholders: {name}, {elem}.
+	Contains Key = "assert.contains"
+
+	// NotContains is the description of an element of a slice that is the
+	// unwanted one.
+	//
+	// Placeholders: {name}, {index}, {got}, {elem}.
+	NotContains Key = "assert.notcontains"
+
+	// Unique is the description of an element of a slice that is a
+	// duplicate of a previous one.
+	//
+	// Placeholders: {name}, {index}, {got}, {other}.
+	Unique Key = "assert.unique"
+
+	// Sorted is the description of an element of a slice that is out of
+	// order.
+	//
+	// Placeholders: {name}, {index}, {got}, {prev}.
+	Sorted Key = "assert.sorted"
+
+	// HasKey is the description of a map that does not have the wanted key.
+	//
+	// Placeholders: {name}, {key}.
+	HasKey Key = "assert.haskey"
+
+	// AllOf is the description of an element of a slice that does not
+	// satisfy the predicate.
+	//
+	// Placeholders: {name}, {index}, {got}.
+	AllOf Key = "assert.allof"
+
+	// DeepMore is the description of the differences that are not listed.
+	//
+	// Placeholders: {count}.
+	DeepMore Key = "assert.deep.more"
+
+	// Invariant is the description of broken invariants.
+	//
+	// Placeholders: {type}, {msgs}.
+	Invariant Key = "assert.invariant"
+
+	// InvariantEntry is the description of invariants broken on method
+	// entry.
+	//
+	// Placeholders: {type}, {msgs}.
+	InvariantEntry Key = "assert.invariant.entry"
+
+	// InvariantExit is the description of invariants broken on method exit.
+	//
+	// Placeholders: {type}, {msgs}.
+	InvariantExit Key = "assert.invariant.exit"
+
+	// Unreachable is the description of code that was reached but should
+	// not have been.
+	Unreachable Key = "assert.unreachable"
+
+	// Bug is the message of a bug.
+	//
+	// Placeholders: {msg}.
+	Bug Key = "assert.bug"
+
+	// BugContext is the message of a bug that has context.
+	//
+	// Placeholders: {msg}, {context}.
+	BugContext Key = "assert.bug.context"
+
+	// Panic is the message of a panic that is not an assertion failure.
+	//
+	// Placeholders: {value}.
+	Panic Key = "assert.panic"
+
+	// ValidateFail is the message of a failed validation.
+	//
+	// Placeholders: {name}, {reason}.
+	ValidateFail Key = "assert.validate"
+
+	// ValidateDefault is the message of a failed validation that has no
+	// failure.
+	ValidateDefault Key = "assert.validate.default"
+
+	// FixFail is the message of a failed fix.
+	//
+	// Placeholders: {name}, {reason}.
+	FixFail Key = "assert.fix"
+
+	// SoftFail is the description of a soft assertion scope in which some
+	// assertions failed.
+	//
+	// Placeholders: {count}, {msgs}.
+	SoftFail Key = "assert.soft"
+
+	// TestWant is the message of a failed test.
+	//
+	// Placeholders: {kind}, {want}, {got}.
+	TestWant Key = "test.want"
+
+	// TestWantNoKind is the message of a failed test that has no kind.
+	//
+	// Placeholders: {want}, {got}.
+	TestWantNoKind Key = "test.want.nokind"
+
+	// TestSomething is the wanted value of a failed test that has none.
+	TestSomething Key = "test.something"
+
+	// TestNothing is the actual value of a failed test that has none.
+	TestNothing Key = "test.nothing"
+
+	// TestPanic is the message of a panic caught by a test.
+	//
+	// Placeholders: {value}.
+	TestPanic Key = "test.panic"
+
+	// TestPanicCase is the message of a panic caught in a test of a TestSet.
+	//
+	// Placeholders: {name}, {value}.
+	TestPanicCase Key = "test.panic.case"
+
+	// TestGoexit is the message of a call to runtime.Goexit caught by a test.
+	TestGoexit Key = "test.goexit"
+
+	// TestSetup is the message of a hook, that runs once for a collection of
+	// tests, that failed.
+	//
+	// Placeholders: {hook}, {err}.
+	TestSetup Key = "test.setup"
+
+	// TestSetupCase is the message of a hook, that runs for a single test,
+	// that failed.
+	//
+	// Placeholders: {hook}, {name}, {err}.
+	TestSetupCase Key = "test.setup.case"
+
+	// TestTimeout is the message of a test that timed out.
+	//
+	// Placeholders: {name}, {timeout}.
+	TestTimeout Key = "test.timeout"
+
+	// TestDecode is the message of a testdata file that could not be
+	// decoded.
+	//
+	// Placeholders: {file}, {line}, {err}.
+	TestDecode Key = "test.decode"
+)
+
+// Catalog is a set of templates, indexed by key.
+type Catalog map[Key]string
+
+var (
+	// catalogs is the registry of catalogs, indexed by locale.
+	catalogs map[string]Catalog = map[string]Catalog{
+		DefaultLocale: {
+			AssertFail:           "[ASSERT FAIL]: {msg}",
+			AssertDefault:        "an assertion was not met",
+			Precondition:         "[PRECONDITION FAIL]: {msg}",
+			PreconditionDefault:  "a precondition was not met",
+			Postcondition:        "[POSTCONDITION FAIL]: {msg}",
+			PostconditionDefault: "a postcondition was not met",
+			IsNil:                "{name} = nil",
+			IsZero:               "{name} is zero",
+			TypeMismatch:         "{name} = {got}, want {want}",
+			IsNilWant:            "{name} = nil, want {want}",
+			IsTypedNil:           "{name} = ({type})(nil)",
+			IsFalse:              "{name} = false",
+			IsTrue:               "{name} = true",
+			IsErr:                "{name} = {err}",
+			Equal:                "{name} = {got}, want {want}",
+			NotEqual:             "{name} = {got}, want != {want}",
+			Less:                 "{name} = {got}, want < {bound}",
+			LessOrEqual:          "{name} = {got}, want <= {bound}",
+			Greater:              "{name} = {got}, want > {bound}",
+			InRange:              "{name} = {got}, want in [{low}, {high})",
+			Between:              "{name} = {got}, want in [{low}, {high}]",
+			Len:                  "len({name}) = {got}, want {want}",
+			Empty:                "{name} is empty",
+			Contains:             "{name} does not contain {elem}",
+			NotContains:          "{name}[{index}] = {got}, want != {elem}",
+			Unique:               "{name}[{index}] = {got}, duplicate of {name}[{other}]",
+			Sorted:               "{name}[{index}] = {got}, want >= {prev}",
+			HasKey:               "{name} has no key {key}",
+			AllOf:                "{name}[{index}] = {got}, want predicate to hold",
+			DeepMore:             "and {count} more",
+			Invariant:            "invariant of {type} = {msgs}",
+			InvariantEntry:       "invariant of {type} = {msgs} on entry",
+			InvariantExit:        "invariant of {type} = {msgs} on exit",
+			Unreachable:          "unreachable code was reached",
+			Bug:                  "[bug] {msg}",
+			BugContext:           "[bug] {msg} ({context})",
+			Panic:                "panic: {value}",
+			ValidateFail:         "(Validate Failed) {name} = {reason}",
+			ValidateDefault:      "(Validate Failed) struct is invalid",
+			FixFail:              "(Fix Failed) {name} = {reason}",
+			SoftFail:             "{count} soft assertion(s) failed: {msgs}",
+			TestWant:             "want {kind} to be {want}, got {got}",
+			TestWantNoKind:       "want {want}, got {got}",
+			TestSomething:        "something",
+			TestNothing:          "nothing",
+			TestPanic:            "panic: {value}",
+			TestPanicCase:        "panic in {name}: {value}",
+			TestGoexit:           "runtime.Goexit was called",
+			TestSetup:            "setup: {hook} failed: {err}",
+			TestSetupCase:        "setup: {hook} failed for {name}: {err}",
+			TestTimeout:          "timeout: {name} did not finish within {timeout}",
+			TestDecode:           "{file}:{line}: {err}",
+		},
+	}
+
+	// locale is the current locale.
+	locale string = DefaultLocale
+
+	// mu protects catalogs and locale.
+	mu sync.RWMutex
+)
+
+// Register adds the templates of the given catalog to the catalog of the
+// locale. Templates already registered for the same keys are replaced.
+//
+// Parameters:
+//   - loc: The locale, such as "fr" or "pt-BR".
+//   - c: The templates to add.
+//
+// Panics:
+//   - "parameter (loc) must not be empty": If loc is empty.
+func Register(loc string, c Catalog) {
+	if loc == "" {
+		panic("parameter (loc) must not be empty")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	dst, ok := catalogs[loc]
+	if !ok {
+		dst = make(Catalog, len(c))
+		catalogs[loc] = dst
+	}
+
+	for key, tmpl := range c {
+		dst[key] = tmpl
+	}
+}
+
+// SetLocale sets the current locale. The locale does not need to be
+// registered; missing templates fall back to the base language, such as
+// "pt" for "pt-BR", and then to DefaultLocale.
+//
+// Parameters:
+//   - loc: The new locale. If empty, DefaultLocale is used.
+//
+// Returns:
+//   - string: The previous locale.
+func SetLocale(loc string) string {
+	if loc == "" {
+		loc = DefaultLocale
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	prev := locale
+	locale = loc
+
+	return prev
+}
+
+// CurrentLocale returns the current locale.
+//
+// Returns:
+//   - string: The current locale.
+func CurrentLocale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return locale
+}
+
+// Lookup returns the template of the key in the current locale.
+//
+// Parameters:
+//   - key: The key of the message.
+//
+// Returns:
+//   - string: The template. The key itself if no locale has a template for
+//     it.
+func Lookup(key Key) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	tmpl, ok := catalogs[locale][key]
+	if ok {
+		return tmpl
+	}
+
+	base, _, found := strings.Cut(locale, "-")
+	if found {
+		tmpl, ok = catalogs[base][key]
+		if ok {
+			return tmpl
+		}
+	}
+
+	tmpl, ok = catalogs[DefaultLocale][key]
+	if ok {
+		return tmpl
+	}
+
+	return string(key)
+}
+
+// Format returns the message of the key in the current locale, with its
+// placeholders replaced by the given values. Placeholders without a value
+// are kept as-is.
+//
+// Parameters:
+//   - key: The key of the message.
+//   - kv: The values of the placeholders, as alternating names and values.
+//
+// Returns:
+//   - string: The message.
+//
+// Example:
+//
+//	str := Format(TypeMismatch, "name", "v", "got", "string", "want", "int")
+//	// str == "v = string, want int"
+func Format(key Key, kv ...string) string {
+	tmpl := Lookup(key)
+
+	str := Expand(tmpl, kv...)
+	return str
+}
+
+// Expand replaces the placeholders of the template by the given values.
+// Placeholders without a value are kept as-is.
+//
+// Parameters:
+//   - tmpl: The template.
+//   - kv: The values of the placeholders, as alternating names and values.
+//
+// Returns:
+//   - string: The expanded template.
+func Expand(tmpl string, kv ...string) string {
+	if len(kv) == 0 || !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+
+	var builder strings.Builder
+
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+
+		end += start
+
+		_, _ = builder.WriteString(tmpl[:start])
+
+		value, ok := lookupValue(kv, tmpl[start+1:end])
+		if ok {
+			_, _ = builder.WriteString(value)
+		} else {
+			_, _ = builder.WriteString(tmpl[start : end+1])
+		}
+
+		tmpl = tmpl[end+1:]
+	}
+
+	_, _ = builder.WriteString(tmpl)
+
+	str := builder.String()
+	return str
+}
+
+// lookupValue returns the value of the placeholder with the given name.
+//
+// Parameters:
+//   - kv: The alternating names and values.
+//   - name: The name of the placeholder.
+//
+// Returns:
+//   - string: The value.
+//   - bool: True if the placeholder has a value, false otherwise.
+func lookupValue(kv []string, name string) (string, bool) {
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i] == name {
+			return kv[i+1], true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,96 @@
+package message
+
+import (
+	"testing"
+)
+
+// TestExpand tests the Expand function.
+func TestExpand(t *testing.T) {
+	type args struct {
+		tmpl string
+		kv   []string
+		want string
+	}
+
+	tests := map[string]args{
+		"no placeholder": {
+			tmpl: "foo",
+			kv:   []string{"name", "v"},
+			want: "foo",
+		},
+		"placeholders": {
+			tmpl: "{name} = {got}, want {want}",
+			kv:   []string{"name", "v", "got", "string", "want", "int"},
+			want: "v = string, want int",
+		},
+		"missing value": {
+			tmpl: "{name} = {got}",
+			kv:   []string{"name", "v"},
+			want: "v = {got}",
+		},
+		"unclosed placeholder": {
+			tmpl: "{name} = {got",
+			kv:   []string{"name", "v", "got", "1"},
+			want: "v = {got",
+		},
+		"value with braces": {
+			tmpl: "[{msg}]",
+			kv:   []string{"msg", "{name}"},
+			want: "[{name}]",
+		},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Expand(args.tmpl, args.kv...)
+			if got != args.want {
+				t.Errorf("want %q, got %q", args.want, got)
+			}
+		})
+	}
+}
+
+// TestLocale tests the lookup of the templates of a locale.
+func TestLocale(t *testing.T) {
+	Register("xx", Catalog{
+		IsNil: "{name} est nil",
+	})
+
+	Register("xx-YY", Catalog{
+		IsZero: "{name} est zéro",
+	})
+
+	prev := SetLocale("xx-YY")
+	defer SetLocale(prev)
+
+	tests := map[string]struct {
+		key  Key
+		want string
+	}{
+		"locale": {
+			key:  IsZero,
+			want: "v est zéro",
+		},
+		"base language": {
+			key:  IsNil,
+			want: "v est nil",
+		},
+		"default locale": {
+			key:  TypeMismatch,
+			want: "v = {got}, want {want}",
+		},
+		"unknown key": {
+			key:  Key("foo"),
+			want: "foo",
+		},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Format(args.key, "name", "v")
+			if got != args.want {
+				t.Errorf("want %q, got %q", args.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package assert
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// ModeEnv is the environment variable read at start-up to select the
+	// assertion mode. Recognized values are "panic", "log" and "count".
+	ModeEnv string = "VERIFY_ASSERT_MODE"
+)
+
+// Mode is the behavior of the assertions when they fail.
+type Mode int32
+
+const (
+	// ModePanic panics with the failure. This is the default mode.
+	ModePanic Mode = iota
+
+	// ModeLog logs the failure to the standard error and continues.
+	ModeLog
+
+	// ModeCount only counts the failure and continues.
+	ModeCount
+)
+
+// String implements fmt.Stringer.
+func (m Mode) String() string {
+	switch m {
+	case ModePanic:
+		return "panic"
+	case ModeLog:
+		return "log"
+	case ModeCount:
+		return "count"
+	default:
+		return "mode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+var (
+	// mode is the current assertion mode.
+	mode atomic.Int32
+
+	// fail_count is the number of failed assertions since start-up.
+	fail_count atomic.Uint64
+
+	// logger is the logger used by ModeLog.
+	logger *log.Logger = log.New(os.Stderr, "", log.LstdFlags)
+)
+
+func init() {
+	str, ok := os.LookupEnv(ModeEnv)
+	if !ok {
+		return
+	}
+
+	m, ok := parseMode(str)
+	if ok {
+		mode.Store(int32(m))
+	}
+}
+
+// parseMode parses the name of a mode.
+//
+// Parameters:
+//   - str: The name of the mode. Case insensitive.
+//
+// Returns:
+//   - Mode: The parsed mode.
+//   - bool: True if the name is valid, false otherwise.
+func parseMode(str string) (Mode, bool) {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "panic":
+		return ModePanic, true
+	case "log":
+		return ModeLog, true
+	case "count":
+		return ModeCount, true
+	default:
+		return ModePanic, false
+	}
+}
+
+// SetMode sets the behavior of the assertions when they fail. Invalid modes
+// are treated as ModePanic.
+//
+// Parameters:
+//   - m: The new mode.
+//
+// Returns:
+//   - Mode: The previous mode.
+//
+// Example:
+//
+//	prev := SetMode(ModeLog)
+//	defer SetMode(prev)
+func SetMode(m Mode) Mode {
+	prev := mode.Swap(int32(m))
+	return Mode(prev)
+}
+
+// CurrentMode returns the current behavior of the assertions when they fail.
+//
+// Returns:
+//   - Mode: The current mode.
+func CurrentMode() Mode {
+	m := mode.Load()
+	return Mode(m)
+}
+
+// FailCount returns the number of assertions that failed since start-up,
+// regardless of the mode.
+//
+// Returns:
+//   - uint64: The number of failed assertions.
+func FailCount() uint64 {
+	count := fail_count.Load()
+	return count
+}
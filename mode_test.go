@@ -0,0 +1,96 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestSetMode tests that the assertions respect the current mode.
+func TestSetMode(t *testing.T) {
+	type args struct {
+		mode       Mode
+		want_err   string
+		want_count uint64
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			prev := SetMode(args.mode)
+			defer SetMode(prev)
+
+			before := FailCount()
+
+			caught := test.Try(func() {
+				Cond(false, "foo")
+				_ = Deref[int](nil, "v")
+			})
+
+			err := test.CHECK.ErrorMessage("", args.want_err, caught)
+			if err != nil {
+				return err
+			}
+
+			err = test.CHECK.Uint("fail count", uint(args.want_count), uint(FailCount()-before))
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("panic", args{
+		mode:       ModePanic,
+		want_err:   NewErrAssertFail("foo").Error(),
+		want_count: 1,
+	})
+
+	_ = tests.Add("count", args{
+		mode:       ModeCount,
+		want_err:   "",
+		want_count: 2,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestParseMode tests the parseMode function.
+func TestParseMode(t *testing.T) {
+	type args struct {
+		str  string
+		want Mode
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			got, _ := parseMode(args.str)
+
+			err := test.CHECK.String("mode", args.want.String(), got.String())
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("log", args{
+		str:  " LOG ",
+		want: ModeLog,
+	})
+
+	_ = tests.Add("count", args{
+		str:  "count",
+		want: ModeCount,
+	})
+
+	_ = tests.Add("invalid", args{
+		str:  "foo",
+		want: ModePanic,
+	})
+
+	_ = tests.Run(t)
+}
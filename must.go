@@ -0,0 +1,192 @@
+package assert
+
+import (
+	"fmt"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
+// Must unwraps the result of a call that returns (T, error). The returned
+// function takes the description of the call and panics with an
+// ErrAssertFail error if the error is not nil. The error is kept reachable
+// through errors.Unwrap.
+//
+// Parameters:
+//   - v: The result of the call.
+//   - err: The error returned by the call.
+//
+// Returns:
+//   - func(format string, args ...any) T: A function that asserts the error
+//     is nil and returns the result. The format string and arguments
+//     describe the call; if empty, "func()" is used. Never returns nil.
+//
+// Example:
+//
+//	f := Must(os.Open(path))("os.Open(%q)", path)
+//	// Panics: os.Open("config.json") = open config.json: no such file or directory
+func Must[T any](v T, err error) func(format string, args ...any) T {
+	return func(format string, args ...any) T {
+		if !enabled {
+			return v
+		}
+
+		mustNoErr(err, format, args)
+
+		return v
+	}
+}
+
+// Must2 is like Must but for calls that return (A, B, error).
+//
+// Parameters:
+//   - a: The first result of the call.
+//   - b: The second result of the call.
+//   - err: The error returned by the call.
+//
+// Returns:
+//   - func(format string, args ...any) (A, B): A function that asserts the
+//     error is nil and returns the results. Never returns nil.
+//
+// Example:
+//
+//	host, port := Must2(net.SplitHostPort(addr))("net.SplitHostPort(%q)", addr)
+func Must2[A, B any](a A, b B, err error) func(format string, args ...any) (A, B) {
+	return func(format string, args ...any) (A, B) {
+		if !enabled {
+			return a, b
+		}
+
+		mustNoErr(err, format, args)
+
+		return a, b
+	}
+}
+
+// Must3 is like Must but for calls that return (A, B, C, error).
+//
+// Parameters:
+//   - a: The first result of the call.
+//   - b: The second result of the call.
+//   - c: The third result of the call.
+//   - err: The error returned by the call.
+//
+// Returns:
+//   - func(format string, args ...any) (A, B, C): A function that asserts
+//     the error is nil and returns the results. Never returns nil.
+func Must3[A, B, C any](a A, b B, c C, err error) func(format string, args ...any) (A, B, C) {
+	return func(format string, args ...any) (A, B, C) {
+		if !enabled {
+			return a, b, c
+		}
+
+		mustNoErr(err, format, args)
+
+		return a, b, c
+	}
+}
+
+// MustOk is like Must but for calls that return (T, bool) in the comma-ok
+// form.
+//
+// Parameters:
+//   - v: The result.
+//   - ok: Whether the result is valid.
+//
+// Returns:
+//   - func(format string, args ...any) T: A function that asserts ok is true
+//     and returns the result. The format string and arguments describe the
+//     expression; if empty, "func()" is used. Never returns nil.
+//
+// Example:
+//
+//	home := MustOk(os.LookupEnv("HOME"))("os.LookupEnv(%q)", "HOME") // Panics: os.LookupEnv("HOME") = false
+func MustOk[T any](v T, ok bool) func(format string, args ...any) T {
+	return func(format string, args ...any) T {
+		if !enabled {
+			return v
+		}
+
+		if ok {
+			return v
+		}
+
+		msg := fmt.Sprintf(format, args...)
+		if msg == "" {
+			msg = "func()"
+		}
+
+		err := newErrAssertFail(message.Format(message.IsFalse, "name", msg))
+		fail(err)
+
+		return v
+	}
+}
+
+// MustNonNil is like Must but it also rejects nil results, including typed
+// nil pointers, maps, slices, channels and functions stored in interfaces.
+//
+// Parameters:
+//   - v: The result of the call.
+//   - err: The error returned by the call.
+//
+// Returns:
+//   - func(format string, args ...any) T: A function that asserts the error
+//     is nil and the result is not nil, and returns the result. Never returns
+//     nil.
+//
+// Example:
+//
+//	db := MustNonNil(OpenDB(dsn))("OpenDB(%q)", dsn) // Panics: OpenDB("...") = (*DB)(nil)
+func MustNonNil[T any](v T, err error) func(format string, args ...any) T {
+	return func(format string, args ...any) T {
+		if !enabled {
+			return v
+		}
+
+		mustNoErr(err, format, args)
+
+		if err != nil || !isNil(v) {
+			return v
+		}
+
+		msg := fmt.Sprintf(format, args...)
+		if msg == "" {
+			msg = "func()"
+		}
+
+		if any(v) == nil {
+			msg = message.Format(message.IsNil, "name", msg)
+		} else {
+			msg = message.Format(message.IsTypedNil, "name", msg, "type", fmt.Sprintf("%T", v))
+		}
+
+		e := newErrAssertFail(msg)
+		e.Kind = KindNil
+
+		fail(e)
+
+		return v
+	}
+}
+
+// mustNoErr fails with an ErrAssertFail that wraps err if err is not nil.
+//
+// Parameters:
+//   - err: The error to check.
+//   - format: The format string of the description.
+//   - args: The arguments of the format string.
+func mustNoErr(err error, format string, args []any) {
+	if err == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if msg == "" {
+		msg = "func()"
+	}
+
+	e := newErrAssertFail(message.Format(message.IsErr, "name", msg, "err", err.Error()))
+	e.Inner = err
+
+	fail(e)
+}
@@ -0,0 +1,99 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestMust tests the Must, Must2, Must3, MustOk and MustNonNil functions.
+func TestMust(t *testing.T) {
+	type args struct {
+		fn   func()
+		want string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(args.fn)
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("Must succeeds", args{
+		fn: func() {
+			n := Must(strconv.Atoi("42"))("strconv.Atoi(%q)", "42")
+			Equal(n, 42, "n")
+		},
+		want: "",
+	})
+
+	_ = tests.Add("Must fails", args{
+		fn: func() {
+			_ = Must(strconv.Atoi("x"))("strconv.Atoi(%q)", "x")
+		},
+		want: NewErrAssertFail(`strconv.Atoi("x") = strconv.Atoi: parsing "x": invalid syntax`).Error(),
+	})
+
+	_ = tests.Add("Must2 fails without description", args{
+		fn: func() {
+			_, _ = Must2(1, "a", io.EOF)("")
+		},
+		want: NewErrAssertFail("func() = EOF").Error(),
+	})
+
+	_ = tests.Add("Must3 succeeds", args{
+		fn: func() {
+			_, _, _ = Must3(1, 2, 3, nil)("")
+		},
+		want: "",
+	})
+
+	_ = tests.Add("MustOk fails", args{
+		fn: func() {
+			_ = MustOk(os.LookupEnv("VERIFY_DOES_NOT_EXIST"))("os.LookupEnv(%q)", "VERIFY_DOES_NOT_EXIST")
+		},
+		want: NewErrAssertFail(`os.LookupEnv("VERIFY_DOES_NOT_EXIST") = false`).Error(),
+	})
+
+	_ = tests.Add("MustNonNil rejects typed nil", args{
+		fn: func() {
+			var r io.Reader = (*os.File)(nil)
+			_ = MustNonNil(r, nil)("open()")
+		},
+		want: NewErrAssertFail("open() = (*os.File)(nil)").Error(),
+	})
+
+	_ = tests.Add("MustNonNil rejects nil interface", args{
+		fn: func() {
+			_ = MustNonNil[io.Reader](nil, nil)("open()")
+		},
+		want: NewErrAssertFail("open() = nil").Error(),
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestMustUnwrap tests that Must keeps the inner error reachable.
+func TestMustUnwrap(t *testing.T) {
+	caught := test.Try(func() {
+		_ = Must(os.Open("does-not-exist"))("os.Open()")
+	})
+
+	ok := errors.Is(caught, os.ErrNotExist)
+	if !ok {
+		t.Errorf("want errors.Is(err, os.ErrNotExist) to be true, got false for %v", caught)
+	}
+}
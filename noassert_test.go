@@ -0,0 +1,29 @@
+//go:build verify_noassert
+
+package assert
+
+import (
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestNoAssert tests that the assertions are no-ops under the
+// "verify_noassert" build tag.
+func TestNoAssert(t *testing.T) {
+	caught := test.Try(func() {
+		Cond(false, "foo")
+		Condf(false, "%s", "foo")
+		True(false, "foo")
+		False(true, "foo")
+		NotZero(0, "v")
+		Type[int]("foo", "v", false)
+		NotNil[int](nil, "v")
+		_ = Conv[int]("foo", "v")
+	})
+
+	err := test.CHECK.ErrorMessage("", "", caught)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,147 @@
+package assert
+
+import (
+	"errors"
+)
+
+// Recover converts a panic caused by a failed assertion into an error. It
+// must be deferred directly by the function to protect, so that the public
+// API of a library never panics on its callers while still using assertions
+// internally. Other panics are propagated unchanged; use RecoverAny to
+// convert them as well.
+//
+// The failures of FoundBug, Unreachable, Require, Ensure, Soft and Validate
+// are assertion failures too.
+//
+// Parameters:
+//   - err: The error to set if a failed assertion is recovered. If nil, the
+//     failure is discarded. Left unchanged if nothing panicked.
+//
+// Example:
+//
+//	func Parse(src string) (tree *Node, err error) {
+//		defer assert.Recover(&err)
+//
+//		// ...
+//	}
+func Recover(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	e, ok := asAssertFailure(r)
+	if !ok {
+		panic(r)
+	}
+
+	if err != nil {
+		*err = e
+	}
+}
+
+// RecoverAny is like Recover but converts every panic into an error. The
+// panics that are not assertion failures are wrapped in an *ErrPanic that
+// holds the panic value and its stack trace.
+//
+// Parameters:
+//   - err: The error to set if a panic is recovered. If nil, the panic is
+//     discarded. Left unchanged if nothing panicked.
+func RecoverAny(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	e, ok := asAssertFailure(r)
+	if !ok {
+		e = newErrPanic(r, panicStack())
+	}
+
+	if err != nil {
+		*err = e
+	}
+}
+
+// Catch runs fn and returns the failed assertion it panics with, if any.
+// Other panics are propagated unchanged.
+//
+// Parameters:
+//   - fn: The function to run. If nil, nil is returned.
+//
+// Returns:
+//   - error: The failed assertion. Nil if fn did not panic.
+//
+// Example:
+//
+//	err := assert.Catch(func() {
+//		tree = parse(src)
+//	})
+func Catch(fn func()) (err error) {
+	if fn == nil {
+		return nil
+	}
+
+	defer Recover(&err)
+
+	fn()
+
+	return nil
+}
+
+// CatchAny is like Catch but converts every panic into an error, as
+// RecoverAny does.
+//
+// Parameters:
+//   - fn: The function to run. If nil, nil is returned.
+//
+// Returns:
+//   - error: The recovered panic. Nil if fn did not panic.
+//
+// Errors:
+//   - *ErrPanic: If the panic is not an assertion failure.
+//   - any other error: The assertion failure, as it was panicked.
+func CatchAny(fn func()) (err error) {
+	if fn == nil {
+		return nil
+	}
+
+	defer RecoverAny(&err)
+
+	fn()
+
+	return nil
+}
+
+// asAssertFailure checks whether the given panic value is an assertion
+// failure of this package.
+//
+// Parameters:
+//   - r: The panic value.
+//
+// Returns:
+//   - error: The panic value as an error. Nil if it is not an assertion
+//     failure.
+//   - bool: True if the panic value is an assertion failure, false otherwise.
+func asAssertFailure(r any) (error, bool) {
+	err, ok := r.(error)
+	if !ok {
+		return nil, false
+	}
+
+	var af *ErrAssertFail
+
+	ok = errors.As(err, &af)
+	if ok {
+		return err, true
+	}
+
+	var vf *ErrValidateFailed
+
+	ok = errors.As(err, &vf)
+	if ok {
+		return err, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,171 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// parse is a mock entry point that recovers its assertion failures.
+func parse(n int) (res int, err error) {
+	defer Recover(&err)
+
+	Cond(n >= 0, "n must be non-negative")
+
+	res = n * 2
+	return res, nil
+}
+
+// TestRecover tests the Recover function.
+func TestRecover(t *testing.T) {
+	type args struct {
+		n        int
+		want_res int
+		want_err string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			res, err := parse(args.n)
+
+			e := test.CHECK.ErrorMessage("", args.want_err, err)
+			if e != nil {
+				return e
+			}
+
+			e = test.CHECK.Int("result", args.want_res, res)
+			return e
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("no failure", args{
+		n:        3,
+		want_res: 6,
+		want_err: "",
+	})
+
+	_ = tests.Add("failure", args{
+		n:        -1,
+		want_res: 0,
+		want_err: NewErrAssertFail("n must be non-negative").Error(),
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestCatch tests the Catch and CatchAny functions.
+func TestCatch(t *testing.T) {
+	type args struct {
+		fn       func()
+		any      bool
+		want_err string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			var err error
+
+			caught := test.Try(func() {
+				if args.any {
+					err = CatchAny(args.fn)
+				} else {
+					err = Catch(args.fn)
+				}
+			})
+
+			if caught != nil {
+				err = caught
+			}
+
+			e := test.CHECK.ErrorMessage("", args.want_err, err)
+			return e
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("nil function", args{
+		fn:       nil,
+		want_err: "",
+	})
+
+	_ = tests.Add("assertion failure", args{
+		fn:       func() { NotZero(0, "v") },
+		want_err: NewErrAssertFail("v is zero").Error(),
+	})
+
+	_ = tests.Add("precondition", args{
+		fn:       func() { Require(false, "n > 0") },
+		want_err: "[PRECONDITION FAIL]: n > 0",
+	})
+
+	_ = tests.Add("bug", args{
+		fn:       func() { FoundBug("foo") },
+		want_err: "[bug] foo",
+	})
+
+	_ = tests.Add("other panic is propagated", args{
+		fn:       func() { panic("foo") },
+		want_err: "foo",
+	})
+
+	_ = tests.Add("other panic is caught", args{
+		fn:       func() { panic("foo") },
+		any:      true,
+		want_err: "panic: foo",
+	})
+
+	_ = tests.Add("assertion failure is kept by CatchAny", args{
+		fn:       func() { NotZero(0, "v") },
+		any:      true,
+		want_err: NewErrAssertFail("v is zero").Error(),
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestCatchAnyRuntimeError tests that CatchAny keeps the runtime error and
+// the stack trace of the panic.
+func TestCatchAnyRuntimeError(t *testing.T) {
+	err := CatchAny(func() {
+		var m map[string]int
+		m["foo"] = 1
+	})
+
+	var re runtime.Error
+
+	ok := errors.As(err, &re)
+	if !ok {
+		t.Fatalf("want a runtime.Error, got %v", err)
+	}
+
+	var pe *ErrPanic
+
+	_ = errors.As(err, &pe)
+
+	if !pe.Runtime {
+		t.Errorf("want Runtime to be true")
+	}
+
+	lines := strings.Split(pe.Stack, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("want a stack trace, got %q", pe.Stack)
+	}
+
+	file, _, _ := strings.Cut(strings.TrimPrefix(lines[1], "\t"), ":")
+
+	if !strings.HasSuffix(file, "recover_test.go") {
+		t.Errorf("want the stack to start in recover_test.go, got %s", lines[1])
+	}
+}
@@ -0,0 +1,382 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-verify/message"
+)
+
+// Scope records the failures of soft assertions instead of panicking. It is
+// created by Soft and SoftErr and must not be used outside of the function
+// it is given to, nor concurrently.
+type Scope struct {
+	// failures is the list of failures recorded so far.
+	failures []*ErrAssertFail
+}
+
+// Soft runs fn with a scope in which the assertions record their failures
+// instead of panicking. At the end of the scope, if at least one assertion
+// failed, it panics once with an ErrSoftFail error that lists every failure.
+// The failure is sent to the handler first, like any other assertion; the
+// handler receives an ErrAssertFail that summarizes the failures and wraps
+// the ErrSoftFail error.
+//
+// Parameters:
+//   - fn: The function to run. Does nothing if nil.
+//
+// Panics:
+//   - *ErrSoftFail: If at least one assertion failed.
+//
+// Example:
+//
+//	Soft(func(s *Scope) {
+//		s.Cond(cfg.Port > 0, "cfg.Port must be positive")
+//		s.NotZero(cfg.Host, "cfg.Host")
+//		s.Type(cfg.Handler, reflect.TypeFor[http.Handler](), "cfg.Handler", false)
+//	})
+//	// Panics:
+//	// [ASSERT FAIL]: cfg.Port must be positive
+//	// [ASSERT FAIL]: cfg.Host is zero
+func Soft(fn func(s *Scope)) {
+	err := softRun(fn)
+	if err == nil {
+		return
+	}
+
+	msgs := make([]string, 0, len(err.Failures))
+
+	for _, failure := range err.Failures {
+		msgs = append(msgs, failure.Msg)
+	}
+
+	af := newErrAssertFail(message.Format(message.SoftFail,
+		"count", strconv.Itoa(len(err.Failures)),
+		"msgs", strings.Join(msgs, "; "),
+	))
+	af.Inner = err
+
+	h := *handler.Load()
+	raise(h, af, err)
+}
+
+// SoftErr is like Soft but returns the aggregate error instead of panicking.
+//
+// Parameters:
+//   - fn: The function to run. Does nothing if nil.
+//
+// Returns:
+//   - error: An *ErrSoftFail error if at least one assertion failed. Nil
+//     otherwise.
+func SoftErr(fn func(s *Scope)) error {
+	err := softRun(fn)
+	if err == nil {
+		return nil
+	}
+
+	return err
+}
+
+// softRun runs fn with a new scope.
+//
+// Parameters:
+//   - fn: The function to run. Does nothing if nil.
+//
+// Returns:
+//   - *ErrSoftFail: The aggregate of the failures. Nil if there are none.
+func softRun(fn func(s *Scope)) *ErrSoftFail {
+	if fn == nil {
+		return nil
+	}
+
+	s := &Scope{}
+
+	fn(s)
+
+	if len(s.failures) == 0 {
+		return nil
+	}
+
+	err := &ErrSoftFail{
+		Failures: s.failures,
+	}
+
+	return err
+}
+
+// record records a failure.
+//
+// Parameters:
+//   - kind: The kind of the failure.
+//   - msg: The error message of the failure.
+//   - inner: The cause of the failure, if any.
+func (s *Scope) record(kind Kind, msg string, inner error) {
+	err := newErrAssertFail(msg)
+	err.Inner = inner
+	err.Kind = kind
+
+	s.failures = append(s.failures, err)
+}
+
+// Failed checks whether at least one assertion of the scope failed so far.
+//
+// Returns:
+//   - bool: True if at least one assertion failed, false otherwise.
+func (s *Scope) Failed() bool {
+	return len(s.failures) > 0
+}
+
+// Cond is like the Cond function but records the failure.
+//
+// Parameters:
+//   - cond: The condition to check.
+//   - msg: The error message to use if the condition is not true.
+func (s *Scope) Cond(cond bool, msg string) {
+	if !enabled || cond {
+		return
+	}
+
+	s.record(KindCondition, msg, nil)
+}
+
+// Condf is like the Condf function but records the failure.
+//
+// Parameters:
+//   - cond: The condition to check.
+//   - format: The format string for the error message to use if the condition is not true.
+//   - args: The arguments to use with the format string.
+func (s *Scope) Condf(cond bool, format string, args ...any) {
+	if !enabled || cond {
+		return
+	}
+
+	s.record(KindCondition, fmt.Sprintf(format, args...), nil)
+}
+
+// Err is like the Err function but records the failure.
+//
+// Parameters:
+//   - inner: The inner error to check.
+//   - format: The format string for the error message.
+//   - args: The arguments to use with the format string.
+func (s *Scope) Err(inner error, format string, args ...any) {
+	if !enabled || inner == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if msg == "" {
+		msg = "func()"
+	}
+
+	s.record(KindCondition, message.Format(message.IsErr, "name", msg, "err", inner.Error()), inner)
+}
+
+// True is like the True function but records the failure.
+//
+// Parameters:
+//   - ok: The boolean condition to check.
+//   - format: The format string for the error message if the condition is false.
+//   - args: The arguments to use with the format string.
+func (s *Scope) True(ok bool, format string, args ...any) {
+	if !enabled || ok {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if msg == "" {
+		msg = "func()"
+	}
+
+	s.record(KindCondition, message.Format(message.IsFalse, "name", msg), nil)
+}
+
+// False is like the False function but records the failure.
+//
+// Parameters:
+//   - ok: The boolean condition to check.
+//   - format: The format string for the error message if the condition is true.
+//   - args: The arguments to use with the format string.
+func (s *Scope) False(ok bool, format string, args ...any) {
+	if !enabled || !ok {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if msg == "" {
+		msg = "func()"
+	}
+
+	s.record(KindCondition, message.Format(message.IsTrue, "name", msg), nil)
+}
+
+// NotZero is like the NotZero function but records the failure. A nil value
+// is considered zero.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - name: The name of the variable. If empty, "variable" is used.
+func (s *Scope) NotZero(v any, name string) {
+	if !enabled {
+		return
+	}
+
+	if v != nil && !reflect.ValueOf(v).IsZero() {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	s.record(KindZero, message.Format(message.IsZero, "name", name), nil)
+}
+
+// NotNil is like the NotNil function but records the failure. Typed nil
+// pointers, maps, slices, channels and functions are considered nil.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - name: The name of the variable. If empty, "variable" is used.
+func (s *Scope) NotNil(v any, name string) {
+	if !enabled || !isNil(v) {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	s.record(KindNil, message.Format(message.IsNil, "name", name), nil)
+}
+
+// Type is like the Type function but records the failure. Since methods
+// cannot have type parameters, the wanted type is given as a reflect.Type.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - typ: The wanted type. If it is an interface type, v must implement it.
+//   - name: The name of the variable. If empty, "variable" is used.
+//   - allow_nil: Whether to allow the variable to be nil.
+//
+// Panics:
+//   - "parameter (typ) must not be nil": If typ is nil.
+//
+// Example:
+//
+//	s.Type(v, reflect.TypeFor[int](), "v", false) // Records: v = string, want int
+func (s *Scope) Type(v any, typ reflect.Type, name string, allow_nil bool) {
+	if !enabled {
+		return
+	}
+
+	if typ == nil {
+		panic("parameter (typ) must not be nil")
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	if v == nil {
+		if !allow_nil {
+			s.record(KindNil, message.Format(message.IsNil, "name", name), nil)
+		}
+
+		return
+	}
+
+	got := reflect.TypeOf(v)
+
+	if got == typ || (typ.Kind() == reflect.Interface && got.Implements(typ)) {
+		return
+	}
+
+	msg := message.Format(message.TypeMismatch,
+		"name", name,
+		"got", fmt.Sprintf("%T", v),
+		"want", typ.String(),
+	)
+
+	s.record(KindType, msg, nil)
+}
+
+// Equal is like the Equal function but records the failure. The values are
+// compared with reflect.DeepEqual.
+//
+// Parameters:
+//   - v: The variable to assert.
+//   - want: The wanted value.
+//   - name: The name of the variable. If empty, "variable" is used.
+func (s *Scope) Equal(v, want any, name string) {
+	if !enabled || reflect.DeepEqual(v, want) {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	s.record(KindCondition, message.Format(message.Equal,
+		"name", name,
+		"got", fmt.Sprint(v),
+		"want", fmt.Sprint(want)), nil,
+	)
+}
+
+// DeepEqual is like the DeepEqual function but records the failure.
+//
+// Parameters:
+//   - got: The actual value.
+//   - want: The wanted value.
+//   - name: The name of the value. If empty, "variable" is used.
+func (s *Scope) DeepEqual(got, want any, name string) {
+	if !enabled || reflect.DeepEqual(got, want) {
+		return
+	}
+
+	if name == "" {
+		name = "variable"
+	}
+
+	s.record(KindCondition, joinDiffs(deepDiff(got, want, name)), nil)
+}
+
+// Context returns a copy of the context that carries a handler that records
+// the failures in the scope, whatever the mode and the process-wide handler
+// are. The assertions that receive the context, such as CondContext, can thus
+// be used in the scope. Like the scope, the context must not be used outside
+// of the function the scope is given to.
+//
+// Parameters:
+//   - ctx: The parent context.
+//
+// Returns:
+//   - context.Context: The derived context. Never returns nil.
+//
+// Panics:
+//   - "parameter (ctx) must not be nil": If ctx is nil.
+//
+// Example:
+//
+//	Soft(func(s *Scope) {
+//		ctx := s.Context(ctx)
+//
+//		CondContext(ctx, cfg.Port > 0, "cfg.Port must be positive")
+//	})
+func (s *Scope) Context(ctx context.Context) context.Context {
+	if ctx == nil {
+		panic("parameter (ctx) must not be nil")
+	}
+
+	h := func(err *ErrAssertFail) bool {
+		s.failures = append(s.failures, err)
+		return false
+	}
+
+	ctx = WithHandler(ctx, h)
+	return ctx
+}
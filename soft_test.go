@@ -0,0 +1,199 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// TestSoft tests the Soft and SoftErr functions.
+func TestSoft(t *testing.T) {
+	type args struct {
+		fn   func(s *Scope)
+		want []string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			err := SoftErr(args.fn)
+
+			var want string
+
+			if len(args.want) > 0 {
+				lines := make([]string, 0, len(args.want))
+
+				for _, msg := range args.want {
+					lines = append(lines, NewErrAssertFail(msg).Error())
+				}
+
+				want = strings.Join(lines, "\n")
+			}
+
+			e := test.CHECK.ErrorMessage("", want, err)
+			if e != nil {
+				return e
+			}
+
+			caught := test.Try(func() {
+				Soft(args.fn)
+			})
+
+			e = test.CHECK.ErrorMessage("", want, caught)
+			return e
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("no failure", args{
+		fn: func(s *Scope) {
+			s.Cond(true, "foo")
+			s.NotZero(1, "n")
+		},
+		want: nil,
+	})
+
+	_ = tests.Add("every failure", args{
+		fn: func(s *Scope) {
+			s.Cond(false, "foo")
+			s.Condf(false, "%s", "bar")
+			s.Err(io.EOF, "Read()")
+			s.True(false, "ok")
+			s.False(true, "ok")
+			s.NotZero("", "host")
+			s.NotNil((*int)(nil), "p")
+			s.Type("foo", reflect.TypeFor[int](), "v", false)
+			s.Type(nil, reflect.TypeFor[int](), "w", true)
+			s.Type(strings.NewReader(""), reflect.TypeFor[io.Reader](), "r", false)
+			s.Equal([]int{1}, []int{2}, "list")
+			s.DeepEqual(map[string]int{"a": 1}, map[string]int{"a": 2}, "m")
+			CondContext(s.Context(context.Background()), false, "baz")
+		},
+		want: []string{
+			"foo",
+			"bar",
+			"Read() = EOF",
+			"ok = false",
+			"ok = true",
+			"host is zero",
+			"p = nil",
+			"v = string, want int",
+			"list = [1], want [2]",
+			`m["a"]: 1 != 2`,
+			"baz",
+		},
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestSoftErrJoin tests that ErrSoftFail is compatible with errors.Is and
+// errors.As.
+func TestSoftErrJoin(t *testing.T) {
+	err := SoftErr(func(s *Scope) {
+		s.Cond(false, "foo")
+		s.Err(io.ErrUnexpectedEOF, "Read()")
+	})
+
+	ok := errors.Is(err, io.ErrUnexpectedEOF)
+	if !ok {
+		t.Errorf("want errors.Is(err, io.ErrUnexpectedEOF) to be true")
+	}
+
+	var af *ErrAssertFail
+
+	ok = errors.As(err, &af)
+	if !ok || af.Msg != "foo" {
+		t.Errorf("want errors.As to find the first failure, got %v", af)
+	}
+}
+
+// TestScopeContext tests that the context of a scope records the failures
+// whatever the mode and the handler are.
+func TestScopeContext(t *testing.T) {
+	type args struct {
+		mode    Mode
+		handler Handler
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			prev_mode := SetMode(args.mode)
+			defer SetMode(prev_mode)
+
+			prev_handler := SetHandler(args.handler)
+			defer SetHandler(prev_handler)
+
+			err := SoftErr(func(s *Scope) {
+				ctx := s.Context(context.Background())
+
+				CondContext(ctx, false, "foo")
+				CondfContext(ctx, false, "n = %d, want < %d", 5, 3)
+			})
+
+			want := strings.Join([]string{
+				NewErrAssertFail("foo").Error(),
+				NewErrAssertFail("n = 5, want < 3").Error(),
+			}, "\n")
+
+			e := test.CHECK.ErrorMessage("", want, err)
+			return e
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("panic", args{
+		mode: ModePanic,
+	})
+
+	_ = tests.Add("count", args{
+		mode: ModeCount,
+	})
+
+	_ = tests.Add("handler that continues", args{
+		mode:    ModePanic,
+		handler: func(err *ErrAssertFail) bool { return false },
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestSoftHandler tests the failure that Soft sends to the handler.
+func TestSoftHandler(t *testing.T) {
+	var got *ErrAssertFail
+
+	prev := SetHandler(func(err *ErrAssertFail) bool {
+		got = err
+		return false
+	})
+	defer SetHandler(prev)
+
+	Soft(func(s *Scope) {
+		s.Cond(false, "foo")
+		s.NotZero("", "host")
+	})
+
+	e := test.CHECK.ErrorMessage("", NewErrAssertFail("2 soft assertion(s) failed: foo; host is zero").Error(), got)
+	if e != nil {
+		t.Error(e)
+	}
+
+	var sf *ErrSoftFail
+
+	ok := errors.As(got, &sf)
+	if !ok {
+		t.Errorf("want the failure to wrap an *ErrSoftFail")
+	}
+}
@@ -0,0 +1,179 @@
+package assert
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// maxStackDepth is the maximum number of frames captured in a stack trace.
+	maxStackDepth int = 32
+)
+
+var (
+	// pkg_prefix is the prefix of the fully qualified names of the functions
+	// of this package.
+	pkg_prefix string = reflect.TypeOf(ErrAssertFail{}).PkgPath() + "."
+
+	// stack_capture is whether the stack trace is captured on failures.
+	stack_capture atomic.Bool
+)
+
+func init() {
+	stack_capture.Store(true)
+}
+
+// SetStackCapture sets whether the full stack trace is captured when an
+// assertion fails. The location of the caller is always recorded. Stack
+// capture is enabled by default.
+//
+// Parameters:
+//   - enabled: Whether to capture the stack trace.
+//
+// Returns:
+//   - bool: The previous setting.
+func SetStackCapture(enabled bool) bool {
+	prev := stack_capture.Swap(enabled)
+	return prev
+}
+
+// Frame is a single frame of a captured stack trace.
+type Frame struct {
+	// Func is the fully qualified name of the function.
+	Func string `json:"func"`
+
+	// File is the path of the source file.
+	File string `json:"file"`
+
+	// Line is the line number in the source file.
+	Line int `json:"line"`
+}
+
+// String implements fmt.Stringer.
+//
+// Format:
+//
+//	"<func>\n\t<file>:<line>"
+func (f Frame) String() string {
+	var builder strings.Builder
+
+	_, _ = builder.WriteString(f.Func)
+	_, _ = builder.WriteString("\n\t")
+	_, _ = builder.WriteString(f.File)
+	_, _ = builder.WriteRune(':')
+	_, _ = builder.WriteString(strconv.Itoa(f.Line))
+
+	str := builder.String()
+	return str
+}
+
+// isInternal checks whether the given frame belongs to this package. Test
+// files of this package are not considered internal.
+//
+// Parameters:
+//   - frame: The frame to check.
+//
+// Returns:
+//   - bool: True if the frame belongs to this package, false otherwise.
+func isInternal(frame runtime.Frame) bool {
+	if !strings.HasPrefix(frame.Function, pkg_prefix) {
+		return false
+	}
+
+	ok := strings.HasSuffix(frame.File, "_test.go")
+	return !ok
+}
+
+// callers returns the stack trace of the current goroutine, starting from the
+// first frame that does not belong to this package.
+//
+// Returns:
+//   - []Frame: The captured frames. Nil if no frame could be captured.
+func callers() []Frame {
+	pcs := make([]uintptr, maxStackDepth)
+
+	n := runtime.Callers(2, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	iter := runtime.CallersFrames(pcs[:n])
+
+	var frames []Frame
+	skipping := true
+
+	for {
+		frame, more := iter.Next()
+
+		if skipping && isInternal(frame) {
+			if !more {
+				break
+			}
+
+			continue
+		}
+
+		skipping = false
+
+		frames = append(frames, Frame{
+			Func: frame.Function,
+			File: frame.File,
+			Line: frame.Line,
+		})
+
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
+
+// panicStack returns the stack trace of the panicking goroutine, starting
+// from the function that panicked. The frames of the runtime that raised the
+// panic, such as a nil pointer dereference, are skipped. It must be called
+// from a deferred function while the goroutine is panicking.
+//
+// Returns:
+//   - string: The captured frames, formatted with Frame.String and separated
+//     by newlines. Empty if no frame could be captured.
+func panicStack() string {
+	pcs := make([]uintptr, maxStackDepth)
+
+	n := runtime.Callers(2, pcs)
+	if n == 0 {
+		return ""
+	}
+
+	iter := runtime.CallersFrames(pcs[:n])
+
+	var frames []string
+	found := false
+
+	for {
+		frame, more := iter.Next()
+
+		if frame.Function == "runtime.gopanic" {
+			frames = frames[:0]
+			found = true
+		} else if found && !isInternal(frame) && (len(frames) > 0 || !strings.HasPrefix(frame.Function, "runtime.")) {
+			f := Frame{
+				Func: frame.Function,
+				File: frame.File,
+				Line: frame.Line,
+			}
+
+			frames = append(frames, f.String())
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	str := strings.Join(frames, "\n")
+	return str
+}
@@ -0,0 +1,228 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// chainOf returns the chain of the given error, in the order errors.Is
+// visits it: the error itself, then its wrapped errors, depth first.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - []error: The chain. Nil if err is nil.
+func chainOf(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	chain := []error{err}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		chain = append(chain, chainOf(e.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			chain = append(chain, chainOf(inner)...)
+		}
+	}
+
+	return chain
+}
+
+// sameErr checks whether two errors of a chain are the same. They are if
+// they are equal, or if they have the same type and the same message.
+//
+// Parameters:
+//   - want: The expected error.
+//   - got: The actual error.
+//
+// Returns:
+//   - bool: True if the errors are the same, false otherwise.
+func sameErr(want, got error) bool {
+	if want == nil || got == nil {
+		return want == got
+	}
+
+	want_val := reflect.ValueOf(want)
+	got_val := reflect.ValueOf(got)
+
+	if want_val.Type() != got_val.Type() {
+		return false
+	}
+
+	// Unlike Type.Comparable, Value.Comparable also looks at the values held
+	// by interface fields, which make == panic if they are not comparable.
+	if want_val.Comparable() && got_val.Comparable() && want == got {
+		return true
+	}
+
+	return want.Error() == got.Error()
+}
+
+// formatChain formats a chain of errors.
+//
+// Parameters:
+//   - chain: The chain.
+//
+// Returns:
+//   - string: The formatted chain.
+//
+// Format:
+//
+//	<type> "<message>" -> <type> "<message>" -> ...
+//
+// If the chain is empty, "no error" is returned.
+func formatChain(chain []error) string {
+	if len(chain) == 0 {
+		return "no error"
+	}
+
+	elems := make([]string, 0, len(chain))
+
+	for _, err := range chain {
+		if err == nil {
+			elems = append(elems, "nil")
+		} else {
+			elems = append(elems, fmt.Sprintf("%T %s", err, strconv.Quote(err.Error())))
+		}
+	}
+
+	str := strings.Join(elems, " -> ")
+	return str
+}
+
+// structOf returns the struct value of the given error.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - reflect.Value: The struct value.
+//   - bool: True if the error is a struct or a non-nil pointer to a struct,
+//     false otherwise.
+func structOf(err error) (reflect.Value, bool) {
+	if err == nil {
+		return reflect.Value{}, false
+	}
+
+	rv := reflect.ValueOf(err)
+
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	return rv, true
+}
+
+// formatFields formats the exported fields of a struct error.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - string: The formatted fields.
+//
+// Format:
+//
+//	<type>{<field>: <value>, ...}
+//
+// If the error is nil, "no error" is returned. If it is not a struct, it is
+// formatted as in formatChain.
+func formatFields(err error) string {
+	if err == nil {
+		return "no error"
+	}
+
+	rv, ok := structOf(err)
+	if !ok {
+		str := formatChain([]error{err})
+		return str
+	}
+
+	var builder strings.Builder
+
+	_, _ = builder.WriteString(fmt.Sprintf("%T", err))
+	_, _ = builder.WriteRune('{')
+
+	typ := rv.Type()
+	first := true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if !first {
+			_, _ = builder.WriteString(", ")
+		}
+
+		first = false
+
+		_, _ = builder.WriteString(field.Name)
+		_, _ = builder.WriteString(": ")
+		_, _ = builder.WriteString(fmt.Sprintf("%#v", rv.Field(i).Interface()))
+	}
+
+	_, _ = builder.WriteRune('}')
+
+	str := builder.String()
+	return str
+}
+
+// sameFields checks whether two struct errors have the same type and the
+// same exported fields.
+//
+// Parameters:
+//   - want: The expected error.
+//   - got: The actual error.
+//
+// Returns:
+//   - bool: True if the errors have the same fields, false otherwise.
+func sameFields(want, got error) bool {
+	if want == nil || got == nil {
+		return want == got
+	}
+
+	if reflect.TypeOf(want) != reflect.TypeOf(got) {
+		return false
+	}
+
+	want_rv, ok := structOf(want)
+	if !ok {
+		return sameErr(want, got)
+	}
+
+	got_rv, ok := structOf(got)
+	if !ok {
+		return false
+	}
+
+	typ := want_rv.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		if !typ.Field(i).IsExported() {
+			continue
+		}
+
+		ok := reflect.DeepEqual(want_rv.Field(i).Interface(), got_rv.Field(i).Interface())
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -2,6 +2,10 @@ package test
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -95,3 +99,220 @@ func TestEqualsErr(t *testing.T) {
 	_ = tests.Run(t)
 }
 */
+
+// TestErrChain tests the ErrChain check.
+func TestErrChain(t *testing.T) {
+	type args struct {
+		want     []error
+		got      error
+		want_err string
+	}
+
+	fn := func(args args) TestingFn {
+		fn := func() error {
+			err := CHECK.ErrChain("", args.want, args.got)
+
+			err = CHECK.ErrorMessage("", args.want_err, err)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.Add("no error", args{
+		want:     nil,
+		got:      nil,
+		want_err: "",
+	})
+
+	_ = tests.Add("same chain", args{
+		want:     []error{fmt.Errorf("load: %w", io.EOF), io.EOF},
+		got:      fmt.Errorf("load: %w", io.EOF),
+		want_err: "",
+	})
+
+	_ = tests.Add("joined errors", args{
+		want:     []error{errors.Join(io.EOF, ErrTestNotImpl), io.EOF, ErrTestNotImpl},
+		got:      errors.Join(io.EOF, ErrTestNotImpl),
+		want_err: "",
+	})
+
+	_ = tests.Add("different chain", args{
+		want:     []error{fmt.Errorf("load: %w", io.EOF), io.EOF},
+		got:      fmt.Errorf("load: %w", io.ErrUnexpectedEOF),
+		want_err: `want *fmt.wrapError "load: EOF" -> *errors.errorString "EOF", got *fmt.wrapError "load: unexpected EOF" -> *errors.errorString "unexpected EOF"`,
+	})
+
+	_ = tests.Add("uncomparable value", args{
+		want:     []error{ErrPanic{Value: []int{1}}},
+		got:      ErrPanic{Value: []int{1}},
+		want_err: "",
+	})
+
+	_ = tests.Add("shorter chain", args{
+		want:     []error{io.EOF},
+		got:      nil,
+		want_err: `want *errors.errorString "EOF", got no error`,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestErrFields tests the ErrFields check.
+func TestErrFields(t *testing.T) {
+	type args struct {
+		want     error
+		got      error
+		want_err string
+	}
+
+	fn := func(args args) TestingFn {
+		fn := func() error {
+			err := CHECK.ErrFields("", args.want, args.got)
+
+			err = CHECK.ErrorMessage("", args.want_err, err)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.Add("same fields", args{
+		want:     &ErrTest{Kind: "length", Want: "3", Got: "4"},
+		got:      NewErrTest("length", "3", "4"),
+		want_err: "",
+	})
+
+	_ = tests.Add("different fields", args{
+		want:     &ErrTest{Kind: "length", Want: "3", Got: "4"},
+		got:      NewErrTest("length", "3", "5"),
+		want_err: `want *test.ErrTest{Kind: "length", Want: "3", Got: "4"}, got *test.ErrTest{Kind: "length", Want: "3", Got: "5"}`,
+	})
+
+	_ = tests.Add("different types", args{
+		want:     &ErrTest{Kind: "length", Want: "3", Got: "4"},
+		got:      NewErrPanic(1),
+		want_err: `want *test.ErrTest{Kind: "length", Want: "3", Got: "4"}, got *test.ErrPanic{Value: 1, Stack: "", Runtime: false, NilPanic: false, Goexit: false, Name: ""}`,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestErrAs tests the ErrAs function.
+func TestErrAs(t *testing.T) {
+	err := fmt.Errorf("load: %w", NewErrPanic(1))
+
+	target, e := ErrAs[*ErrPanic]("", err)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if target.Value != 1 {
+		t.Errorf("want value to be 1, got %v", target.Value)
+	}
+
+	_, e = ErrAs[*ErrTest]("", err)
+
+	e = CHECK.ErrorMessage("", `want an error of type *test.ErrTest, got *fmt.wrapError "load: panic: 1" -> *test.ErrPanic "panic: 1"`, e)
+	if e != nil {
+		t.Error(e)
+	}
+}
+
+// TestTryPanicKinds tests that Try tells the kinds of panic apart.
+func TestTryPanicKinds(t *testing.T) {
+	type args struct {
+		fn            func()
+		want_err      string
+		want_runtime  bool
+		want_nilpanic bool
+		want_goexit   bool
+	}
+
+	fn := func(args args) TestingFn {
+		fn := func() error {
+			caught := Try(args.fn)
+
+			err := CHECK.ErrorMessage("", args.want_err, caught)
+			if err != nil {
+				return err
+			}
+
+			target, err := ErrAs[*ErrPanic]("", caught)
+			if err != nil {
+				return err
+			}
+
+			if !strings.Contains(target.Stack, "checkers_test.go") {
+				err := NewErrTest("stack", "the location of the panic", target.Stack)
+				return err
+			}
+
+			got := fmt.Sprintf("%t %t %t", target.Runtime, target.NilPanic, target.Goexit)
+			want := fmt.Sprintf("%t %t %t", args.want_runtime, args.want_nilpanic, args.want_goexit)
+
+			err = CHECK.String("runtime, nil panic and goexit", want, got)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.Add("value", args{
+		fn:       func() { panic(42) },
+		want_err: "panic: 42",
+	})
+
+	_ = tests.Add("runtime error", args{
+		fn: func() {
+			var s []int
+			_ = s[1]
+		},
+		want_err:     "panic: runtime error: index out of range [1] with length 0",
+		want_runtime: true,
+	})
+
+	_ = tests.Add("panic nil", args{
+		fn:            func() { panic(nil) },
+		want_err:      "panic: " + new(runtime.PanicNilError).Error(),
+		want_runtime:  true,
+		want_nilpanic: true,
+	})
+
+	_ = tests.Add("goexit", args{
+		fn:          func() { runtime.Goexit() },
+		want_err:    "runtime.Goexit was called",
+		want_goexit: true,
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestTryValue tests the TryValue function.
+func TestTryValue(t *testing.T) {
+	s := []int{1, 2, 3}
+
+	v, err := TryValue(func() int { return s[1] })
+	if err != nil || v != 2 {
+		t.Errorf("want 2 and no error, got %d and %v", v, err)
+	}
+
+	idx := 10
+
+	v, err = TryValue(func() int { return s[idx] })
+
+	err = CHECK.ErrorMessage("", "panic: runtime error: index out of range [10] with length 3", err)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if v != 0 {
+		t.Errorf("want 0, got %d", v)
+	}
+}
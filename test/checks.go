@@ -2,6 +2,7 @@ package test
 
 import (
 	"errors"
+	"reflect"
 )
 
 // checkT is for private use only
@@ -93,6 +94,114 @@ func (checkT) Err(kind string, want, got error) error {
 	return err
 }
 
+// ErrChain checks that the chain of the actual error is exactly the given
+// sequence of errors, in the order errors.Is visits it: the error itself,
+// then its wrapped errors, depth first. Two errors of the chains match if
+// they are equal, or if they have the same type and the same message.
+//
+// Parameters:
+//   - kind: The kind of the value.
+//   - want: The expected chain. If empty, got must be nil.
+//   - got: The actual error.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrTest, if the check fails.
+//
+// Example:
+//
+//	err := load() // returns fmt.Errorf("load: %w", io.EOF)
+//	err = CHECK.ErrChain("", []error{fmt.Errorf("load: %w", io.EOF), io.EOF}, err)
+func (checkT) ErrChain(kind string, want []error, got error) error {
+	chain := chainOf(got)
+
+	if len(chain) == len(want) {
+		ok := true
+
+		for i, w := range want {
+			if !sameErr(w, chain[i]) {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			return nil
+		}
+	}
+
+	err := FAIL.ErrChain(kind, want, got)
+	return err
+}
+
+// ErrFields checks that the expected and actual errors have the same type
+// and the same exported fields, compared with reflect.DeepEqual. Errors that
+// are not structs, nor pointers to structs, are compared as in ErrChain.
+//
+// Parameters:
+//   - kind: The kind of the value.
+//   - want: The expected error.
+//   - got: The actual error.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrTest, if the check fails.
+//
+// Example:
+//
+//	err = CHECK.ErrFields("", &ErrTest{Kind: "length", Want: "3", Got: "4"}, err)
+func (checkT) ErrFields(kind string, want, got error) error {
+	ok := sameFields(want, got)
+	if ok {
+		return nil
+	}
+
+	err := FAIL.ErrFields(kind, want, got)
+	return err
+}
+
+// ErrAs checks that an error in the chain of the actual error is of type T,
+// as errors.As does. Since methods cannot have type parameters, this is not
+// part of the CHECK namespace.
+//
+// Parameters:
+//   - kind: The kind of the value.
+//   - got: The actual error.
+//
+// Returns:
+//   - T: The first error of type T in the chain. The zero value if there is
+//     none.
+//   - error: A pointer to the newly created ErrTest, if the check fails.
+//
+// Format:
+//
+//	"want <kind> to be an error of type <type>, got <chain>"
+//
+// Where:
+//   - <type> is the type T.
+//   - <chain> is the chain of the actual error, or "no error" if it is nil.
+//
+// Example:
+//
+//	perr, err := ErrAs[*fs.PathError]("", err)
+//	if err != nil {
+//		return err
+//	}
+func ErrAs[T error](kind string, got error) (T, error) {
+	var target T
+
+	ok := errors.As(got, &target)
+	if ok {
+		return target, nil
+	}
+
+	err := &ErrTest{
+		Kind: kind,
+		Want: "an error of type " + reflect.TypeFor[T]().String(),
+		Got:  formatChain(chainOf(got)),
+	}
+
+	return target, err
+}
+
 // ErrorMessage checks that the given expected and actual error messages are
 // equal. If not the proper error is returned.
 //
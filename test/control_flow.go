@@ -1,36 +1,137 @@
 package test
 
-import "errors"
+import (
+	"errors"
+	"runtime"
+	"runtime/debug"
+)
 
-// try executes the given function and stores any paniced error in err.
+// outcome is the outcome of a function that ran in its own goroutine.
+type outcome struct {
+	// panicked is whether the function panicked.
+	panicked bool
+
+	// value is the value of the panic.
+	value any
+
+	// stack is the stack trace of the panic or of the call to
+	// runtime.Goexit.
+	stack string
+
+	// goexit is whether the function called runtime.Goexit.
+	goexit bool
+}
+
+// asError returns the error that describes the outcome.
 //
-// Parameters:
-//   - err: The error to store the paniced error in. If the given function
-//     does not panic, the value of err is not changed.
-//   - fn: The function to execute.
-func try(err *error, fn func()) {
-	defer func() {
-		r := recover()
-		if r == nil {
-			return
+// Returns:
+//   - error: The error. Nil if the function returned normally.
+//
+// If the panic value is an error, other than a runtime error, it is returned
+// as-is. If the panic value is a string, it is converted to an error. In any
+// other case, a new ErrPanic is created. The stack trace of the panic is only
+// kept in the ErrPanic errors.
+func (o outcome) asError() error {
+	if o.goexit {
+		err := &ErrPanic{
+			Stack:  o.stack,
+			Goexit: true,
 		}
 
-		switch r := r.(type) {
-		case string:
-			*err = errors.New(r)
-		case error:
-			*err = r
-		default:
-			*err = NewErrPanic(r)
-		}
+		return err
+	}
+
+	if !o.panicked {
+		return nil
+	}
+
+	switch r := o.value.(type) {
+	case string:
+		return errors.New(r)
+	case runtime.Error:
+		err := newPanic(r, o.stack)
+		return err
+	case error:
+		return r
+	default:
+		err := newPanic(r, o.stack)
+		return err
+	}
+}
+
+// spawn executes the given function in a new goroutine. Running the function
+// in its own goroutine allows the calls to runtime.Goexit, such as t.FailNow,
+// to be detected.
+//
+// Parameters:
+//   - fn: The function to execute. Must not be nil.
+//
+// Returns:
+//   - <-chan outcome: The channel that receives the outcome of the function
+//     once it has finished. Never returns nil.
+func spawn(fn func()) <-chan outcome {
+	ch := make(chan outcome, 1)
+
+	go func() {
+		var res outcome
+
+		returned := false
+
+		defer func() {
+			r := recover()
+			if r != nil {
+				res.panicked = true
+				res.value = r
+				res.stack = string(debug.Stack())
+			} else if !returned {
+				res.goexit = true
+				res.stack = string(debug.Stack())
+			}
+
+			ch <- res
+		}()
+
+		fn()
+
+		returned = true
 	}()
 
-	fn()
+	return ch
+}
+
+// newPanic creates an ErrPanic for the given panic value, with the flags
+// that describe it.
+//
+// Parameters:
+//   - r: The recovered value.
+//   - stack: The stack trace of the panic.
+//
+// Returns:
+//   - *ErrPanic: The new error. Never returns nil.
+func newPanic(r any, stack string) *ErrPanic {
+	err := &ErrPanic{
+		Value: r,
+		Stack: stack,
+	}
+
+	switch r.(type) {
+	case *runtime.PanicNilError:
+		err.Runtime = true
+		err.NilPanic = true
+	case runtime.Error:
+		err.Runtime = true
+	}
+
+	return err
 }
 
 // Try executes the given function and returns any paniced error. If the given
 // function does not panic, nil is returned.
 //
+// The function is executed in its own goroutine, and Try waits for it to
+// finish. If it calls runtime.Goexit, such as through t.FailNow, an ErrPanic
+// with Goexit set is returned.
+//
 // Parameters:
 //   - fn: The function to execute. If nil, nil is returned.
 //
@@ -38,11 +139,16 @@ func try(err *error, fn func()) {
 //   - error: The paniced error, if any. Otherwise, nil.
 //
 // Errors:
-//   - *ErrPanic: If the panic value is not an error.
-//   - any other error: If the panic value is an error.
+//   - *ErrPanic: If the panic value is neither a string nor an error, if it
+//     is a runtime error, or if the function called runtime.Goexit.
+//   - any other error: If the panic value is a string or an error.
 //
-// If the panic value is an error, it is returned as-is. If the panic value is a string, it is
-// converted to an error. In any other case, a new ErrPanic is created.
+// If the panic value is an error, other than a runtime error, it is returned
+// as-is. If the panic value is a string, it is converted to an error with
+// errors.New. In any other case, a new ErrPanic is created. Only the ErrPanic
+// errors carry the stack trace of the panic; the stack trace of a string or
+// error panic is lost, since its error is the panic value itself or an error
+// with the same message.
 //
 // Example:
 //
@@ -56,9 +162,40 @@ func Try(fn func()) error {
 		return nil
 	}
 
-	var err error
-
-	try(&err, fn)
+	res := <-spawn(fn)
 
+	err := res.asError()
 	return err
 }
+
+// TryValue is like Try but for functions that return a value. The errors,
+// and the panics that keep their stack trace, are the same as for Try.
+//
+// Parameters:
+//   - fn: The function to execute. If nil, the zero value and nil are
+//     returned.
+//
+// Returns:
+//   - T: The value returned by the function. The zero value if it panicked.
+//   - error: The paniced error, if any. Otherwise, nil.
+//
+// Example:
+//
+//	v, err := TryValue(func() int {
+//		return s[10]
+//	})
+//	// err: panic: runtime error: index out of range [10] with length 3
+func TryValue[T any](fn func() T) (T, error) {
+	var res T
+
+	if fn == nil {
+		return res, nil
+	}
+
+	out := <-spawn(func() {
+		res = fn()
+	})
+
+	err := out.asError()
+	return res, err
+}
@@ -1,9 +1,14 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/PlayerR9/go-verify/message"
 )
 
 var (
@@ -41,31 +46,23 @@ func (e ErrTest) Error() string {
 	var want, got string
 
 	if e.Want == "" {
-		want = "something"
+		want = message.Format(message.TestSomething)
 	} else {
 		want = e.Want
 	}
 
 	if e.Got == "" {
-		got = "nothing"
+		got = message.Format(message.TestNothing)
 	} else {
 		got = e.Got
 	}
 
 	if e.Kind == "" {
-		return "want " + want + ", got " + got
+		str := message.Format(message.TestWantNoKind, "want", want, "got", got)
+		return str
 	}
 
-	var builder strings.Builder
-
-	_, _ = builder.WriteString("want ")
-	_, _ = builder.WriteString(e.Kind)
-	_, _ = builder.WriteString(" to be ")
-	_, _ = builder.WriteString(want)
-	_, _ = builder.WriteString(", got ")
-	_, _ = builder.WriteString(got)
-
-	str := builder.String()
+	str := message.Format(message.TestWant, "kind", e.Kind, "want", want, "got", got)
 	return str
 }
 
@@ -105,13 +102,77 @@ func NewErrTest(kind, want, got string) error {
 
 // ErrPanic is an error that represents a panic.
 type ErrPanic struct {
-	// Value is the value of the panic.
+	// Value is the value of the panic. Nil if Goexit is true.
 	Value any
+
+	// Stack is the stack trace of the goroutine that panicked, as formatted
+	// by runtime/debug.Stack. Empty if unknown.
+	Stack string
+
+	// Runtime is whether the value of the panic is a runtime.Error, such as
+	// an index out of range or a nil pointer dereference.
+	Runtime bool
+
+	// NilPanic is whether the panic was a panic(nil), reported by the runtime
+	// as a *runtime.PanicNilError.
+	NilPanic bool
+
+	// Goexit is whether the function called runtime.Goexit, such as through
+	// t.FailNow, instead of panicking.
+	Goexit bool
+
+	// Name is the name of the test that panicked. Empty if unknown.
+	Name string
 }
 
 // Error implements the error interface.
 func (e ErrPanic) Error() string {
-	return fmt.Sprintf("panic: %v", e.Value)
+	if e.Goexit {
+		str := message.Format(message.TestGoexit)
+		return str
+	}
+
+	value := fmt.Sprintf("%v", e.Value)
+
+	if e.Name == "" {
+		str := message.Format(message.TestPanic, "value", value)
+		return str
+	}
+
+	str := message.Format(message.TestPanicCase, "name", e.Name, "value", value)
+	return str
+}
+
+// Format implements fmt.Formatter.
+//
+// The verbs %s and %v print the error message. The verb %+v additionally
+// prints the stack trace of the panic. The verb %q prints the quoted error
+// message.
+func (e ErrPanic) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, e.Error())
+
+		if s.Flag('+') && e.Stack != "" {
+			_, _ = io.WriteString(s, "\n\n"+e.Stack)
+		}
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(test.ErrPanic=%s)", verb, e.Error())
+	}
+}
+
+// Unwrap returns the value of the panic if it is an error, such as a
+// runtime.Error.
+//
+// Returns:
+//   - error: The value of the panic. Nil if it is not an error.
+func (e ErrPanic) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
 }
 
 // NewErrPanic creates a new error that represents a panic.
@@ -134,3 +195,213 @@ func NewErrPanic(value any) error {
 
 	return err
 }
+
+// ErrSetup occurs when a setup or teardown hook of a TestSet failed. It is
+// reported apart from the ErrTest errors of the tests themselves.
+type ErrSetup struct {
+	// Hook is the name of the hook, such as "BeforeEach".
+	Hook string
+
+	// Name is the name of the test the hook ran for. Empty for the hooks
+	// that run once for every test.
+	Name string
+
+	// Err is the error returned by the hook.
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrSetup) Error() string {
+	var msg string
+
+	if e.Err == nil {
+		msg = message.Format(message.TestSomething)
+	} else {
+		msg = e.Err.Error()
+	}
+
+	if e.Name == "" {
+		str := message.Format(message.TestSetup, "hook", e.Hook, "err", msg)
+		return str
+	}
+
+	str := message.Format(message.TestSetupCase, "hook", e.Hook, "name", e.Name, "err", msg)
+	return str
+}
+
+// Unwrap returns the error returned by the hook.
+//
+// Returns:
+//   - error: The error returned by the hook.
+func (e ErrSetup) Unwrap() error {
+	return e.Err
+}
+
+// NewErrSetup creates a new error that represents a failed hook.
+//
+// Parameters:
+//   - hook: The name of the hook.
+//   - name: The name of the test. Empty for the hooks that run once.
+//   - err: The error returned by the hook.
+//
+// Returns:
+//   - error: The new error. Never returns nil.
+//
+// Format:
+//
+//	"setup: <hook> failed for <name>: <err>"
+//
+// where:
+//   - <hook> is the name of the hook.
+//   - <name> is the name of the test. " for <name>" is omitted if empty.
+//   - <err> is the error returned by the hook.
+func NewErrSetup(hook, name string, err error) error {
+	e := &ErrSetup{
+		Hook: hook,
+		Name: name,
+		Err:  err,
+	}
+
+	return e
+}
+
+// ErrTimeout occurs when a test overruns its timeout or the deadline of go
+// test.
+type ErrTimeout struct {
+	// Name is the name of the test.
+	Name string
+
+	// Timeout is the duration the test was given.
+	Timeout time.Duration
+
+	// Dump is the stack traces of every goroutine when the test timed out.
+	Dump string
+}
+
+// Error implements the error interface.
+func (e ErrTimeout) Error() string {
+	str := message.Format(message.TestTimeout, "name", e.Name, "timeout", e.Timeout.String())
+	return str
+}
+
+// Format implements fmt.Formatter.
+//
+// The verbs %s and %v print the error message. The verb %+v additionally
+// prints the stack traces of every goroutine. The verb %q prints the quoted
+// error message.
+func (e ErrTimeout) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, e.Error())
+
+		if s.Flag('+') && e.Dump != "" {
+			_, _ = io.WriteString(s, "\n\n"+e.Dump)
+		}
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(test.ErrTimeout=%s)", verb, e.Error())
+	}
+}
+
+// Unwrap returns context.DeadlineExceeded, so that a timeout can be checked
+// with errors.Is.
+//
+// Returns:
+//   - error: context.DeadlineExceeded.
+func (e ErrTimeout) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// NewErrTimeout creates a new error that represents a test that timed out.
+//
+// Parameters:
+//   - name: The name of the test.
+//   - timeout: The duration the test was given.
+//   - dump: The stack traces of every goroutine.
+//
+// Returns:
+//   - error: The new error. Never returns nil.
+//
+// Format:
+//
+//	"timeout: <name> did not finish within <timeout>"
+//
+// where:
+//   - <name> is the name of the test.
+//   - <timeout> is the duration the test was given.
+func NewErrTimeout(name string, timeout time.Duration, dump string) error {
+	err := &ErrTimeout{
+		Name:    name,
+		Timeout: timeout,
+		Dump:    dump,
+	}
+
+	return err
+}
+
+// ErrDecode occurs when a record of a testdata file could not be decoded.
+type ErrDecode struct {
+	// File is the path of the file.
+	File string
+
+	// Line is the line of the record, or of the value, that could not be
+	// decoded.
+	Line int
+
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrDecode) Error() string {
+	var msg string
+
+	if e.Err == nil {
+		msg = message.Format(message.TestSomething)
+	} else {
+		msg = e.Err.Error()
+	}
+
+	str := message.Format(message.TestDecode, "file", e.File, "line", strconv.Itoa(e.Line), "err", msg)
+	return str
+}
+
+// Unwrap returns the reason of the failure.
+//
+// Returns:
+//   - error: The reason of the failure.
+func (e ErrDecode) Unwrap() error {
+	return e.Err
+}
+
+// NewErrDecode creates a new error that represents a record of a testdata
+// file that could not be decoded.
+//
+// Parameters:
+//   - file: The path of the file.
+//   - line: The line of the record.
+//   - err: The reason of the failure.
+//
+// Returns:
+//   - error: The new error. Never returns nil.
+//
+// Format:
+//
+//	"<file>:<line>: <err>"
+//
+// where:
+//   - <file> is the path of the file.
+//   - <line> is the line of the record.
+//   - <err> is the reason of the failure.
+func NewErrDecode(file string, line int, err error) error {
+	e := &ErrDecode{
+		File: file,
+		Line: line,
+		Err:  err,
+	}
+
+	return e
+}
@@ -180,6 +180,70 @@ func (failT) Err(kind string, want, got error) error {
 	return err
 }
 
+// ErrChain creates and returns a new ErrTest error with the given expected
+// chain and the chain of the actual error.
+//
+// Parameters:
+//   - kind: The kind of the value.
+//   - want: The expected chain.
+//   - got: The actual error.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrTest. Never returns nil.
+//
+// Format:
+//
+//	"want <kind> to be <want>, got <got>"
+//
+// Where:
+//   - <kind> is the kind of the value.
+//   - <want> is the expected chain, as `<type> "<message>" -> ...`, or "no
+//     error" if it is empty.
+//   - <got> is the chain of the actual error, in the same format.
+//
+// If the kind is empty, "want <want>, got <got>" is used.
+func (failT) ErrChain(kind string, want []error, got error) error {
+	err := &ErrTest{
+		Kind: kind,
+		Want: formatChain(want),
+		Got:  formatChain(chainOf(got)),
+	}
+
+	return err
+}
+
+// ErrFields creates and returns a new ErrTest error with the exported fields
+// of the given expected and actual errors.
+//
+// Parameters:
+//   - kind: The kind of the value.
+//   - want: The expected error.
+//   - got: The actual error.
+//
+// Returns:
+//   - error: A pointer to the newly created ErrTest. Never returns nil.
+//
+// Format:
+//
+//	"want <kind> to be <want>, got <got>"
+//
+// Where:
+//   - <kind> is the kind of the value.
+//   - <want> is the expected error, as `<type>{<field>: <value>, ...}`, or
+//     "no error" if it is nil.
+//   - <got> is the actual error, in the same format.
+//
+// If the kind is empty, "want <want>, got <got>" is used.
+func (failT) ErrFields(kind string, want, got error) error {
+	err := &ErrTest{
+		Kind: kind,
+		Want: formatFields(want),
+		Got:  formatFields(got),
+	}
+
+	return err
+}
+
 // Any creates and returns a new ErrTest error with the given expected and
 // actual values.
 //
@@ -1,5 +1,13 @@
 package test
 
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
 // TestingFn is a function that is used to run a test.
 //
 // Parameters:
@@ -24,11 +32,144 @@ var (
 //   - TestingFn: The function that is used to run the test. Never returns nil.
 type MakeFn[T any] func(args T) TestingFn
 
+// ContextTestingFn is like TestingFn but receives a context that is done
+// when the test overruns its deadline.
+//
+// Parameters:
+//   - ctx: The context of the test. Never nil.
+//
+// Returns:
+//   - error: An error if the test failed.
+type ContextTestingFn func(ctx context.Context) error
+
+// MakeContextFn is like MakeFn but creates ContextTestingFn instances.
+//
+// Parameters:
+//   - args: The arguments to pass to the testing function.
+//
+// Returns:
+//   - ContextTestingFn: The function that is used to run the test. Never
+//     returns nil.
+type MakeContextFn[T any] func(args T) ContextTestingFn
+
+const (
+	// deadlineGrace is how long before the deadline of go test a test is
+	// considered to have timed out, so that it can be reported before the
+	// whole test binary is killed.
+	deadlineGrace time.Duration = time.Second
+)
+
 // Instance is an instance of a test.
 type Instance struct {
 	// name is the name of the test.
 	name string
 
 	// fn is the function that is used to run the test.
-	fn TestingFn
+	fn ContextTestingFn
+
+	// timeout is the maximum duration of the test. Zero if only the deadline
+	// of go test applies.
+	timeout time.Duration
+}
+
+// run runs the test and reports its error, if any. The test runs in its own
+// goroutine so that it can be reported as timed out when it overruns its
+// timeout or the deadline of t. A timed out test keeps running in the
+// background.
+//
+// Parameters:
+//   - t: The testing.T instance to use for reporting.
+//   - repanic: Whether a panic of the test is propagated instead of being
+//     reported as an ErrPanic error. If true, the test runs on the goroutine
+//     of t instead, so it is only reported as timed out if it returns.
+//
+// Returns:
+//   - bool: False if the test timed out, true otherwise.
+func (inst Instance) run(t *testing.T, repanic bool) bool {
+	ctx := context.Background()
+	timeout := inst.timeout
+
+	deadline, ok := t.Deadline()
+	if ok {
+		if time.Until(deadline) > 2*deadlineGrace {
+			deadline = deadline.Add(-deadlineGrace)
+		}
+
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+
+		if timeout == 0 || time.Until(deadline) < timeout {
+			timeout = time.Until(deadline)
+		}
+	}
+
+	if inst.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, inst.timeout)
+		defer cancel()
+	}
+
+	var err error
+
+	if repanic {
+		// Run on the goroutine of t, so that a panic keeps its stack.
+		err = inst.fn(ctx)
+	} else {
+		ch := spawn(func() {
+			err = inst.fn(ctx)
+		})
+
+		var res outcome
+
+		select {
+		case res = <-ch:
+		case <-ctx.Done():
+			t.Errorf("%+v", NewErrTimeout(inst.name, timeout, goroutineDump()))
+			return false
+		}
+
+		switch {
+		case res.panicked:
+			e := newPanic(res.value, res.stack)
+			e.Name = inst.name
+
+			t.Errorf("%+v", e)
+			return true
+		case res.goexit:
+			runtime.Goexit()
+		}
+	}
+
+	if err == nil {
+		return true
+	}
+
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		t.Errorf("%+v", NewErrTimeout(inst.name, timeout, goroutineDump()))
+		return true
+	}
+
+	t.Error(err)
+
+	return true
+}
+
+// goroutineDump returns the stack traces of every goroutine.
+//
+// Returns:
+//   - string: The stack traces, as formatted by runtime.Stack.
+func goroutineDump() string {
+	buf := make([]byte, 64<<10)
+
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
 }
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/PlayerR9/go-verify/internal/schema"
+)
+
+const (
+	// SchemaVersion is the version of the JSON schema of ErrTest and
+	// ErrPanic. It is shared with the errors of the assert package.
+	SchemaVersion int = schema.Version
+)
+
+const (
+	// testType is the value of the "type" field of an ErrTest.
+	testType string = "test"
+
+	// panicType is the value of the "type" field of an ErrPanic.
+	panicType string = "panic"
+)
+
+// errTestJSON is the JSON form of ErrTest.
+//
+// Format:
+//
+//	{"schema": 1, "type": "test", "kind": "length", "want": "3", "got": "4"}
+type errTestJSON struct {
+	Schema int    `json:"schema"`
+	Type   string `json:"type"`
+	Kind   string `json:"kind,omitempty"`
+	Want   string `json:"want"`
+	Got    string `json:"got"`
+}
+
+// errPanicJSON is the JSON form of ErrPanic.
+//
+// Format:
+//
+//	{
+//		"schema": 1,
+//		"type": "panic",
+//		"value": "42",
+//		"value_type": "int",
+//		"stack": "goroutine 7 [running]:...",
+//		"runtime": false,
+//		"nil_panic": false,
+//		"goexit": false,
+//		"name": "case"
+//	}
+type errPanicJSON struct {
+	Schema    int    `json:"schema"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	ValueType string `json:"value_type"`
+	Stack     string `json:"stack,omitempty"`
+	Runtime   bool   `json:"runtime,omitempty"`
+	NilPanic  bool   `json:"nil_panic,omitempty"`
+	Goexit    bool   `json:"goexit,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The document always carries the "schema" field, set to SchemaVersion.
+func (e ErrTest) MarshalJSON() ([]byte, error) {
+	data := errTestJSON{
+		Schema: SchemaVersion,
+		Type:   testType,
+		Kind:   e.Kind,
+		Want:   e.Want,
+		Got:    e.Got,
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//   - error: If the data is not valid JSON, if the schema version is newer
+//     than SchemaVersion, or if the type is not "test".
+func (e *ErrTest) UnmarshalJSON(b []byte) error {
+	if e == nil {
+		return ErrNilReceiver
+	}
+
+	var data errTestJSON
+
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+
+	err = schema.Check(data.Schema, data.Type, testType)
+	if err != nil {
+		return err
+	}
+
+	*e = ErrTest{
+		Kind: data.Kind,
+		Want: data.Want,
+		Got:  data.Got,
+	}
+
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The failure is logged as a group with the "kind", "want" and "got"
+// attributes. The "kind" attribute is omitted if empty.
+func (e ErrTest) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 3)
+
+	if e.Kind != "" {
+		attrs = append(attrs, slog.String("kind", e.Kind))
+	}
+
+	attrs = append(attrs,
+		slog.String("want", e.Want),
+		slog.String("got", e.Got),
+	)
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Since the value of the panic can be of any type, it is encoded as its %v
+// representation, and its type as its %T representation. The document always
+// carries the "schema" field, set to SchemaVersion.
+func (e ErrPanic) MarshalJSON() ([]byte, error) {
+	data := errPanicJSON{
+		Schema:    SchemaVersion,
+		Type:      panicType,
+		Value:     fmt.Sprintf("%v", e.Value),
+		ValueType: fmt.Sprintf("%T", e.Value),
+		Stack:     e.Stack,
+		Runtime:   e.Runtime,
+		NilPanic:  e.NilPanic,
+		Goexit:    e.Goexit,
+		Name:      e.Name,
+	}
+
+	b, err := json.Marshal(data)
+	return b, err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Since only the representation of the value of the panic is encoded, the
+// decoded value is that representation, as a string. It is nil if Goexit is
+// set.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//   - error: If the data is not valid JSON, if the schema version is newer
+//     than SchemaVersion, or if the type is not "panic".
+func (e *ErrPanic) UnmarshalJSON(b []byte) error {
+	if e == nil {
+		return ErrNilReceiver
+	}
+
+	var data errPanicJSON
+
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+
+	err = schema.Check(data.Schema, data.Type, panicType)
+	if err != nil {
+		return err
+	}
+
+	*e = ErrPanic{
+		Value:    data.Value,
+		Stack:    data.Stack,
+		Runtime:  data.Runtime,
+		NilPanic: data.NilPanic,
+		Goexit:   data.Goexit,
+		Name:     data.Name,
+	}
+
+	if data.Goexit {
+		e.Value = nil
+	}
+
+	return nil
+}
+
+// LogValue implements slog.LogValuer.
+//
+// The panic is logged as a group with the "value" and "value_type"
+// attributes and, when set, the "runtime", "nil_panic" and "goexit" flags
+// and the "name" of the test. The stack trace is not logged.
+func (e ErrPanic) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Any("value", e.Value),
+		slog.String("value_type", fmt.Sprintf("%T", e.Value)),
+	}
+
+	if e.Runtime {
+		attrs = append(attrs, slog.Bool("runtime", true))
+	}
+
+	if e.NilPanic {
+		attrs = append(attrs, slog.Bool("nil_panic", true))
+	}
+
+	if e.Goexit {
+		attrs = append(attrs, slog.Bool("goexit", true))
+	}
+
+	if e.Name != "" {
+		attrs = append(attrs, slog.String("name", e.Name))
+	}
+
+	v := slog.GroupValue(attrs...)
+	return v
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestErrTestJSON tests the round trip of ErrTest through JSON.
+func TestErrTestJSON(t *testing.T) {
+	want := &ErrTest{
+		Kind: "length",
+		Want: "3",
+		Got:  "4",
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	err = CHECK.String("json", `{"schema":1,"type":"test","kind":"length","want":"3","got":"4"}`, string(b))
+	if err != nil {
+		t.Error(err)
+	}
+
+	var got ErrTest
+
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	if got != *want {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	err = json.Unmarshal([]byte(`{"schema":1,"type":"panic"}`), &got)
+
+	err = CHECK.ErrorMessage("", `type = "panic", want "test"`, err)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestErrPanicJSON tests the round trip of ErrPanic through JSON.
+func TestErrPanicJSON(t *testing.T) {
+	b, err := json.Marshal(NewErrPanic(42))
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	err = CHECK.String("json", `{"schema":1,"type":"panic","value":"42","value_type":"int"}`, string(b))
+	if err != nil {
+		t.Error(err)
+	}
+
+	var got ErrPanic
+
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	err = CHECK.String("message", "panic: 42", got.Error())
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = json.Unmarshal([]byte(`{"schema":3,"type":"panic"}`), &got)
+
+	err = CHECK.ErrorMessage("", "schema version 3 is not supported, want at most 1", err)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestErrTestLogValue tests that ErrTest is logged as fields.
+func TestErrTestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Error("test failed", "err", NewErrTest("", "3", "4"))
+
+	want := `"err":{"want":"3","got":"4"}`
+
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("want %s to be logged, got %s", want, buf.String())
+	}
+}
@@ -0,0 +1,685 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// NameKey is the field, or column, of a record that holds the name of
+	// the test. Like the other keys, it is matched case-insensitively. If a
+	// record has none, the test is named after its location, as
+	// "<file>:<line>".
+	NameKey string = "name"
+)
+
+// record is a record read from a testdata file.
+type record struct {
+	// line is the line where the record starts.
+	line int
+
+	// name is the name of the test. Empty if the record has none.
+	name string
+
+	// keys are the keys of the values, in the order they are decoded.
+	keys []string
+
+	// values are the values, indexed by key.
+	values map[string]recordValue
+}
+
+// recordValue is a value of a record.
+type recordValue struct {
+	// line is the line of the value.
+	line int
+
+	// raw is the raw JSON value. Nil if the value is text.
+	raw json.RawMessage
+
+	// text is the text of the value.
+	text string
+}
+
+// Load is like LoadJSON, LoadYAML or LoadCSV depending on the extension of
+// the file: ".json", ".yaml" or ".yml", and ".csv".
+//
+// Parameters:
+//   - path: The path of the file, such as "testdata/cases.json".
+//
+// Returns:
+//   - error: An error if the tests could not be loaded.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//   - *ErrDecode: If a record could not be decoded.
+//   - error: If the file could not be read or its extension is not
+//     supported.
+func (tt *TestSet[T]) Load(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err := tt.LoadJSON(path)
+		return err
+	case ".yaml", ".yml":
+		err := tt.LoadYAML(path)
+		return err
+	case ".csv":
+		err := tt.LoadCSV(path)
+		return err
+	default:
+		err := fmt.Errorf("extension of %q is not supported", path)
+		return err
+	}
+}
+
+// LoadJSON adds a test for each object of the JSON array stored in the file.
+// Each object is decoded into a T, whose exported fields are matched by their
+// "test" tag, their "json" tag or their name, case-insensitively. The
+// NameKey member holds the name of the test. The tests are only added if
+// every object is decoded.
+//
+// Parameters:
+//   - path: The path of the file, such as "testdata/cases.json".
+//
+// Returns:
+//   - error: An error if the tests could not be loaded.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//   - *ErrDecode: If an object could not be decoded.
+//   - error: If the file could not be read.
+//
+// Example:
+//
+//	// testdata/cases.json:
+//	// [
+//	//   {"name": "empty", "input": "", "want": 0},
+//	//   {"name": "one", "input": "a", "want": 1}
+//	// ]
+//
+//	err := tests.LoadJSON("testdata/cases.json")
+func (tt *TestSet[T]) LoadJSON(path string) error {
+	if tt == nil {
+		return ErrNilReceiver
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	records, err := readJSON(path, data)
+	if err != nil {
+		return err
+	}
+
+	err = tt.addRecords(path, records)
+	return err
+}
+
+// LoadYAML adds a test for each item of the YAML-like list stored in the
+// file. Only a subset of YAML is supported: a list of flat mappings whose
+// values are scalars, optionally quoted with double quotes. Lines that start
+// with "#" are comments. The fields are matched as in LoadJSON.
+//
+// Parameters:
+//   - path: The path of the file, such as "testdata/cases.yaml".
+//
+// Returns:
+//   - error: An error if the tests could not be loaded.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//   - *ErrDecode: If an item could not be decoded.
+//   - error: If the file could not be read.
+//
+// Example:
+//
+//	// testdata/cases.yaml:
+//	// - name: empty
+//	//   input: ""
+//	//   want: 0
+//	// - name: one
+//	//   input: a
+//	//   want: 1
+//
+//	err := tests.LoadYAML("testdata/cases.yaml")
+func (tt *TestSet[T]) LoadYAML(path string) error {
+	if tt == nil {
+		return ErrNilReceiver
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	records, err := readYAML(path, data)
+	if err != nil {
+		return err
+	}
+
+	err = tt.addRecords(path, records)
+	return err
+}
+
+// LoadCSV adds a test for each row of the CSV file. The first row is the
+// header that names the columns. The fields are matched as in LoadJSON.
+//
+// Parameters:
+//   - path: The path of the file, such as "testdata/cases.csv".
+//
+// Returns:
+//   - error: An error if the tests could not be loaded.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//   - *ErrDecode: If a row could not be decoded.
+//   - error: If the file could not be read.
+//
+// Example:
+//
+//	// testdata/cases.csv:
+//	// name,input,want
+//	// empty,,0
+//	// one,a,1
+//
+//	err := tests.LoadCSV("testdata/cases.csv")
+func (tt *TestSet[T]) LoadCSV(path string) error {
+	if tt == nil {
+		return ErrNilReceiver
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	records, err := readCSV(path, data)
+	if err != nil {
+		return err
+	}
+
+	err = tt.addRecords(path, records)
+	return err
+}
+
+// addRecords decodes the records and adds them as tests.
+//
+// Parameters:
+//   - path: The path of the file the records were read from.
+//   - records: The records.
+//
+// Returns:
+//   - error: An *ErrDecode error if a record could not be decoded.
+func (tt *TestSet[T]) addRecords(path string, records []record) error {
+	names := make([]string, 0, len(records))
+	args := make([]T, 0, len(records))
+
+	for _, rec := range records {
+		var v T
+
+		err := decodeRecord(path, rec, reflect.ValueOf(&v).Elem())
+		if err != nil {
+			return err
+		}
+
+		name := rec.name
+		if name == "" {
+			name = filepath.Base(path) + ":" + strconv.Itoa(rec.line)
+		}
+
+		names = append(names, name)
+		args = append(args, v)
+	}
+
+	for i, name := range names {
+		err := tt.Add(name, args[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readJSON reads the records of a JSON file.
+//
+// Parameters:
+//   - path: The path of the file.
+//   - data: The content of the file.
+//
+// Returns:
+//   - []record: The records.
+//   - error: An *ErrDecode error if the file is not an array of objects or if
+//     anything follows the array.
+func readJSON(path string, data []byte) ([]record, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		err := NewErrDecode(path, lineAt(data, dec.InputOffset()), err)
+		return nil, err
+	}
+
+	if tok != json.Delim('[') {
+		err := NewErrDecode(path, lineAt(data, dec.InputOffset()), errors.New("want an array of objects"))
+		return nil, err
+	}
+
+	var records []record
+
+	for dec.More() {
+		line := lineAt(data, skipSpace(data, dec.InputOffset()))
+
+		var obj map[string]json.RawMessage
+
+		err := dec.Decode(&obj)
+		if err != nil {
+			err := NewErrDecode(path, line, err)
+			return nil, err
+		}
+
+		rec := record{
+			line:   line,
+			values: make(map[string]recordValue, len(obj)),
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			raw := obj[key]
+
+			if strings.EqualFold(key, NameKey) {
+				_ = json.Unmarshal(raw, &rec.name)
+			}
+
+			rec.keys = append(rec.keys, key)
+			rec.values[key] = recordValue{
+				line: line,
+				raw:  raw,
+			}
+		}
+
+		records = append(records, rec)
+	}
+
+	// The closing bracket, which dec.More has already checked.
+	_, err = dec.Token()
+	if err != nil {
+		err := NewErrDecode(path, lineAt(data, dec.InputOffset()), err)
+		return nil, err
+	}
+
+	offset := skipSpace(data, dec.InputOffset())
+
+	_, err = dec.Token()
+	if err != io.EOF {
+		err := NewErrDecode(path, lineAt(data, offset), errors.New("unexpected data after the array"))
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// readYAML reads the records of a YAML-like file.
+//
+// Parameters:
+//   - path: The path of the file.
+//   - data: The content of the file.
+//
+// Returns:
+//   - []record: The records.
+//   - error: An *ErrDecode error if a line is not valid.
+func readYAML(path string, data []byte) ([]record, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	var records []record
+	var line int
+
+	for scanner.Scan() {
+		line++
+
+		str := strings.TrimRight(scanner.Text(), " \t\r")
+
+		trimmed := strings.TrimSpace(str)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		after, ok := strings.CutPrefix(str, "- ")
+		if ok {
+			records = append(records, record{
+				line:   line,
+				values: make(map[string]recordValue),
+			})
+
+			str = after
+		} else if trimmed == "-" {
+			records = append(records, record{
+				line:   line,
+				values: make(map[string]recordValue),
+			})
+
+			continue
+		} else if len(records) == 0 || (str[0] != ' ' && str[0] != '\t') {
+			err := NewErrDecode(path, line, errors.New("want a list item that starts with \"- \""))
+			return nil, err
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(str), ":")
+		if !ok {
+			err := NewErrDecode(path, line, fmt.Errorf("want \"key: value\", got %q", trimmed))
+			return nil, err
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if strings.HasPrefix(value, "\"") {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				err := NewErrDecode(path, line, fmt.Errorf("value of %q is not a valid quoted string", key))
+				return nil, err
+			}
+
+			value = unquoted
+		}
+
+		rec := &records[len(records)-1]
+
+		_, ok = rec.values[key]
+		if ok {
+			err := NewErrDecode(path, line, fmt.Errorf("duplicate key %q", key))
+			return nil, err
+		}
+
+		if strings.EqualFold(key, NameKey) {
+			rec.name = value
+		}
+
+		rec.keys = append(rec.keys, key)
+		rec.values[key] = recordValue{
+			line: line,
+			text: value,
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// readCSV reads the records of a CSV file.
+//
+// Parameters:
+//   - path: The path of the file.
+//   - data: The content of the file.
+//
+// Returns:
+//   - []record: The records.
+//   - error: An *ErrDecode error if the file is not valid CSV.
+func readCSV(path string, data []byte) ([]record, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		err := csvErr(path, err)
+		return nil, err
+	}
+
+	for i, key := range header {
+		header[i] = strings.TrimSpace(key)
+	}
+
+	var records []record
+
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			err := csvErr(path, err)
+			return nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+
+		rec := record{
+			line:   line,
+			keys:   header,
+			values: make(map[string]recordValue, len(header)),
+		}
+
+		for i, key := range header {
+			if strings.EqualFold(key, NameKey) {
+				rec.name = row[i]
+			}
+
+			rec.values[key] = recordValue{
+				line: line,
+				text: row[i],
+			}
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// csvErr converts an error of the CSV reader into an *ErrDecode error.
+//
+// Parameters:
+//   - path: The path of the file.
+//   - err: The error of the CSV reader.
+//
+// Returns:
+//   - error: The converted error.
+func csvErr(path string, err error) error {
+	var perr *csv.ParseError
+
+	ok := errors.As(err, &perr)
+	if !ok {
+		return err
+	}
+
+	err = NewErrDecode(path, perr.Line, perr.Err)
+	return err
+}
+
+// decodeRecord decodes a record into a struct.
+//
+// Parameters:
+//   - path: The path of the file the record was read from.
+//   - rec: The record.
+//   - v: The settable struct value.
+//
+// Returns:
+//   - error: An *ErrDecode error if the record could not be decoded.
+func decodeRecord(path string, rec record, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		err := NewErrDecode(path, rec.line, fmt.Errorf("cannot decode into %s, want a struct", v.Type()))
+		return err
+	}
+
+	for _, key := range rec.keys {
+		value := rec.values[key]
+
+		field, ok := fieldByKey(v, key)
+		if !ok {
+			if strings.EqualFold(key, NameKey) {
+				continue
+			}
+
+			err := NewErrDecode(path, value.line, fmt.Errorf("unknown field %q", key))
+			return err
+		}
+
+		var err error
+
+		if value.raw != nil {
+			err = json.Unmarshal(value.raw, field.Addr().Interface())
+		} else {
+			err = setText(field, value.text)
+		}
+
+		if err != nil {
+			err := NewErrDecode(path, value.line, fmt.Errorf("field %q: %w", key, err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldByKey returns the exported field of the struct that matches the key.
+// A field matches if its "test" tag, its "json" tag or its name is equal to
+// the key, case-insensitively, in that order of precedence.
+//
+// Parameters:
+//   - v: The struct value.
+//   - key: The key.
+//
+// Returns:
+//   - reflect.Value: The field.
+//   - bool: True if a field matches, false otherwise.
+func fieldByKey(v reflect.Value, key string) (reflect.Value, bool) {
+	typ := v.Type()
+
+	for _, tag := range []string{"test", "json", ""} {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			var name string
+
+			if tag == "" {
+				name = field.Name
+			} else {
+				name, _, _ = strings.Cut(field.Tag.Get(tag), ",")
+			}
+
+			if name == "" || name == "-" {
+				continue
+			}
+
+			if strings.EqualFold(name, key) {
+				return v.Field(i), true
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// setText sets a field from its text representation.
+//
+// Parameters:
+//   - field: The settable field.
+//   - text: The text.
+//
+// Returns:
+//   - error: An error if the text is not valid for the type of the field.
+func setText(field reflect.Value, text string) error {
+	tu, ok := field.Addr().Interface().(encoding.TextUnmarshaler)
+	if ok {
+		err := tu.UnmarshalText([]byte(text))
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(f)
+	default:
+		err := json.Unmarshal([]byte(text), field.Addr().Interface())
+		return err
+	}
+
+	return nil
+}
+
+// lineAt returns the line of the given offset.
+//
+// Parameters:
+//   - data: The content of the file.
+//   - offset: The offset.
+//
+// Returns:
+//   - int: The line, starting from 1.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	line := 1 + bytes.Count(data[:offset], []byte{'\n'})
+	return line
+}
+
+// skipSpace returns the offset of the first byte that is not a JSON
+// whitespace nor a comma, starting from the given offset.
+//
+// Parameters:
+//   - data: The content of the file.
+//   - offset: The offset.
+//
+// Returns:
+//   - int64: The offset of the first significant byte.
+func skipSpace(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
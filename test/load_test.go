@@ -0,0 +1,218 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// lenCase is a test vector loaded from testdata.
+type lenCase struct {
+	Input   string `json:"input"`
+	WantLen int    `test:"want_len"`
+	Upper   bool
+}
+
+// TestLoad tests that the loaders read the same test vectors from every
+// format.
+func TestLoad(t *testing.T) {
+	for _, path := range []string{
+		"testdata/cases.json",
+		"testdata/cases.yaml",
+		"testdata/cases.csv",
+	} {
+		var got []string
+
+		fn := func(args lenCase) TestingFn {
+			fn := func() error {
+				got = append(got, fmt.Sprintf("%q %t", args.Input, args.Upper))
+
+				err := CHECK.Int("length", args.WantLen, utf8.RuneCountInString(args.Input))
+				return err
+			}
+
+			return fn
+		}
+
+		tests := NewTestSet(fn)
+
+		err := tests.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := make([]string, 0, len(tests.instances))
+
+		for _, instance := range tests.instances {
+			names = append(names, instance.name)
+		}
+
+		want := "empty,one," + filepath.Base(path) + ":"
+
+		if !strings.HasPrefix(strings.Join(names, ","), want) {
+			t.Errorf("want names to start with %q, got %q", want, strings.Join(names, ","))
+		}
+
+		_ = tests.Run(t)
+
+		err = CHECK.String("inputs of "+path, `"" false,"a" true,"héllo" false`, strings.Join(got, ","))
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// TestLoadErrors tests that the loaders report the file and the line of the
+// records that cannot be decoded.
+func TestLoadErrors(t *testing.T) {
+	type args struct {
+		file     string
+		content  string
+		want_err string
+	}
+
+	dir := t.TempDir()
+
+	fn := func(args args) TestingFn {
+		fn := func() error {
+			path := filepath.Join(dir, args.file)
+
+			err := os.WriteFile(path, []byte(args.content), 0o644)
+			if err != nil {
+				return err
+			}
+
+			tests := NewTestSet(func(args lenCase) TestingFn {
+				return nil
+			})
+
+			err = tests.Load(path)
+
+			err = CHECK.ErrorMessage("", path+args.want_err, err)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.Add("json type", args{
+		file:     "type.json",
+		content:  "[\n  {\"input\": \"a\"},\n  {\"input\": 1}\n]\n",
+		want_err: `:3: field "input": json: cannot unmarshal number into Go value of type string`,
+	})
+
+	_ = tests.Add("json unknown field", args{
+		file:     "unknown.json",
+		content:  "[{\"foo\": 1}]",
+		want_err: `:1: unknown field "foo"`,
+	})
+
+	_ = tests.Add("json syntax", args{
+		file:     "syntax.json",
+		content:  "[\n  {\"input\": \"a\"},\n  {\"input\" \"b\"}\n]\n",
+		want_err: ":3: invalid character '\"' after object key",
+	})
+
+	_ = tests.Add("json trailing data", args{
+		file:     "trailing.json",
+		content:  "[\n  {\"input\": \"a\"}\n]\n{\"input\": \"b\"}\n",
+		want_err: ":4: unexpected data after the array",
+	})
+
+	_ = tests.Add("json unclosed", args{
+		file:     "unclosed.json",
+		content:  "[\n  {\"input\": \"a\"}\n",
+		want_err: ":3: unexpected end of JSON input",
+	})
+
+	_ = tests.Add("yaml value", args{
+		file:     "value.yaml",
+		content:  "- name: a\n  input: a\n  want_len: one\n",
+		want_err: `:3: field "want_len": strconv.ParseInt: parsing "one": invalid syntax`,
+	})
+
+	_ = tests.Add("yaml item", args{
+		file:     "item.yaml",
+		content:  "name: a\n",
+		want_err: `:1: want a list item that starts with "- "`,
+	})
+
+	_ = tests.Add("csv value", args{
+		file:     "value.csv",
+		content:  "name,upper\na,true\nb,maybe\n",
+		want_err: `:3: field "upper": strconv.ParseBool: parsing "maybe": invalid syntax`,
+	})
+
+	_ = tests.Add("csv columns", args{
+		file:     "columns.csv",
+		content:  "name,upper\na,true,1\n",
+		want_err: ":2: wrong number of fields",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestLoadNameKey tests that the name of a record is found whatever the case
+// of its key.
+func TestLoadNameKey(t *testing.T) {
+	type args struct {
+		file    string
+		content string
+	}
+
+	dir := t.TempDir()
+
+	fn := func(args args) TestingFn {
+		fn := func() error {
+			path := filepath.Join(dir, args.file)
+
+			err := os.WriteFile(path, []byte(args.content), 0o644)
+			if err != nil {
+				return err
+			}
+
+			tests := NewTestSet(func(args lenCase) TestingFn {
+				return nil
+			})
+
+			err = tests.Load(path)
+			if err != nil {
+				return err
+			}
+
+			err = CHECK.Int("number of tests", 1, len(tests.instances))
+			if err != nil {
+				return err
+			}
+
+			err = CHECK.String("name", "foo", tests.instances[0].name)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.Add("json", args{
+		file:    "name.json",
+		content: `[{"Name": "foo", "input": "a"}]`,
+	})
+
+	_ = tests.Add("yaml", args{
+		file:    "name.yaml",
+		content: "- NAME: foo\n  input: a\n",
+	})
+
+	_ = tests.Add("csv", args{
+		file:    "name.csv",
+		content: "Name,input\nfoo,a\n",
+	})
+
+	_ = tests.Run(t)
+}
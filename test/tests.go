@@ -1,18 +1,57 @@
 package test
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestSet is a collection of tests.
 type TestSet[T any] struct {
-	// makeFn is a function that is used to create TestingFn instances.
-	makeFn MakeFn[T]
+	// makeFn is a function that is used to create ContextTestingFn
+	// instances.
+	makeFn MakeContextFn[T]
 
 	// instances is the collection of tests.
 	instances []Instance
+
+	// args are the arguments of the tests, in the same order as instances.
+	args []T
+
+	// beforeAll are the hooks run once before the tests.
+	beforeAll []Hook
+
+	// afterAll are the hooks run once after the tests.
+	afterAll []Hook
+
+	// beforeEach are the hooks run before each test.
+	beforeEach []CaseHook[T]
+
+	// afterEach are the hooks run after each test.
+	afterEach []CaseHook[T]
+
+	// repanic is whether the panics of the tests are propagated instead of
+	// being reported.
+	repanic bool
 }
 
+// Hook is a function that sets up or tears down a collection of tests.
+//
+// Returns:
+//   - error: An error if the hook failed.
+type Hook func() error
+
+// CaseHook is a function that sets up or tears down a single test.
+//
+// Parameters:
+//   - name: The name of the test.
+//   - args: The arguments of the test.
+//
+// Returns:
+//   - error: An error if the hook failed.
+type CaseHook[T any] func(name string, args T) error
+
 // NewTestSet creates and returns a new TestSet instance with a specified
 // function to create TestingFn instances. If no function is provided,
 // a default testing function is used.
@@ -30,6 +69,58 @@ func NewTestSet[T any](makeFn MakeFn[T]) TestSet[T] {
 		}
 	}
 
+	fn := func(args T) ContextTestingFn {
+		fn := makeFn(args)
+
+		ctx_fn := func(_ context.Context) error {
+			err := fn()
+			return err
+		}
+
+		return ctx_fn
+	}
+
+	ts := TestSet[T]{
+		makeFn: fn,
+	}
+
+	return ts
+}
+
+// NewContextTestSet is like NewTestSet but the testing functions receive a
+// context. The context is done when the test overruns the timeout given to
+// AddTimeout or the deadline of go test, whichever comes first.
+//
+// Parameters:
+//   - makeFn: A function that returns a ContextTestingFn. If nil, a default
+//     testing function is used.
+//
+// Returns:
+//   - TestSet[T]: A new Tests instance with the provided or default makeFn.
+//
+// Example:
+//
+//	fn := func(args args) test.ContextTestingFn {
+//		return func(ctx context.Context) error {
+//			res, err := client.Get(ctx, args.url)
+//			// ...
+//		}
+//	}
+//
+//	tests := test.NewContextTestSet(fn)
+//	_ = tests.AddTimeout("slow server", args{url: slow_url}, 2*time.Second)
+func NewContextTestSet[T any](makeFn MakeContextFn[T]) TestSet[T] {
+	if makeFn == nil {
+		makeFn = func(_ T) ContextTestingFn {
+			fn := func(_ context.Context) error {
+				err := DefaultTestingFn()
+				return err
+			}
+
+			return fn
+		}
+	}
+
 	ts := TestSet[T]{
 		makeFn: makeFn,
 	}
@@ -49,20 +140,254 @@ func NewTestSet[T any](makeFn MakeFn[T]) TestSet[T] {
 // Errors:
 //   - ErrNilReceiver: If the receiver is nil.
 func (tt *TestSet[T]) Add(name string, args T) error {
+	err := tt.AddTimeout(name, args, 0)
+	return err
+}
+
+// AddTimeout is like Add but the test times out after the given duration.
+// A test that times out is reported with an ErrTimeout error that includes
+// the stack traces of every goroutine.
+//
+// Parameters:
+//   - name: The name of the test.
+//   - args: The arguments to pass to the testing function.
+//   - timeout: The maximum duration of the test. If zero or negative, only
+//     the deadline of go test applies.
+//
+// Returns:
+//   - error: An error if the test could not be added.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+func (tt *TestSet[T]) AddTimeout(name string, args T, timeout time.Duration) error {
 	if tt == nil {
 		return ErrNilReceiver
 	}
 
+	if timeout < 0 {
+		timeout = 0
+	}
+
 	instance := Instance{
-		name: name,
-		fn:   tt.makeFn(args),
+		name:    name,
+		fn:      tt.makeFn(args),
+		timeout: timeout,
 	}
 
 	tt.instances = append(tt.instances, instance)
+	tt.args = append(tt.args, args)
+
+	return nil
+}
+
+// SetRepanic sets whether a test that panics makes the whole test binary
+// panic, as it would without a TestSet. By default, the panic is recovered
+// and reported as an ErrPanic error with the name of the test and the stack
+// trace of the panic, the test is counted as failed and the other tests
+// still run. Propagating the panic can help when debugging.
+//
+// When the panics are propagated, the tests run on the goroutine of their
+// subtest, so that the panic keeps its original stack trace. A test that
+// overruns its timeout is then only reported once it returns.
+//
+// Parameters:
+//   - repanic: Whether to propagate the panics.
+//
+// Returns:
+//   - error: An error if the option could not be set.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+func (tt *TestSet[T]) SetRepanic(repanic bool) error {
+	if tt == nil {
+		return ErrNilReceiver
+	}
+
+	tt.repanic = repanic
+
+	return nil
+}
+
+// BeforeAll adds a hook that runs once before the tests. If it fails, the
+// tests are not run and every test is counted as failed.
+//
+// Parameters:
+//   - fn: The hook. Does nothing if nil.
+//
+// Returns:
+//   - error: An error if the hook could not be added.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+func (tt *TestSet[T]) BeforeAll(fn Hook) error {
+	if tt == nil {
+		return ErrNilReceiver
+	} else if fn == nil {
+		return nil
+	}
+
+	tt.beforeAll = append(tt.beforeAll, fn)
+
+	return nil
+}
+
+// AfterAll adds a hook that runs once after the tests, through the Cleanup
+// method of the testing.T given to Run. It only runs if the BeforeAll hooks
+// succeeded. The hooks run in the reverse order they were added.
+//
+// Parameters:
+//   - fn: The hook. Does nothing if nil.
+//
+// Returns:
+//   - error: An error if the hook could not be added.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+func (tt *TestSet[T]) AfterAll(fn Hook) error {
+	if tt == nil {
+		return ErrNilReceiver
+	} else if fn == nil {
+		return nil
+	}
+
+	tt.afterAll = append(tt.afterAll, fn)
+
+	return nil
+}
+
+// BeforeEach adds a hook that runs before each test. If it fails, the test
+// is not run and is counted as failed.
+//
+// Parameters:
+//   - fn: The hook. Does nothing if nil.
+//
+// Returns:
+//   - error: An error if the hook could not be added.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+func (tt *TestSet[T]) BeforeEach(fn CaseHook[T]) error {
+	if tt == nil {
+		return ErrNilReceiver
+	} else if fn == nil {
+		return nil
+	}
+
+	tt.beforeEach = append(tt.beforeEach, fn)
+
+	return nil
+}
+
+// AfterEach adds a hook that runs after each test, through the Cleanup method
+// of the subtest, even if the test failed or panicked. It only runs if the
+// BeforeEach hooks succeeded. The hooks run in the reverse order they were
+// added.
+//
+// The hooks do not run for a test that timed out, since it may still be
+// running in the background and using what the hooks would tear down.
+//
+// Parameters:
+//   - fn: The hook. Does nothing if nil.
+//
+// Returns:
+//   - error: An error if the hook could not be added.
+//
+// Errors:
+//   - ErrNilReceiver: If the receiver is nil.
+//
+// Example:
+//
+//	_ = tests.BeforeEach(func(name string, args args) error {
+//		return os.MkdirAll(filepath.Join(dir, name), 0o755)
+//	})
+//
+//	_ = tests.AfterEach(func(name string, args args) error {
+//		return os.RemoveAll(filepath.Join(dir, name))
+//	})
+func (tt *TestSet[T]) AfterEach(fn CaseHook[T]) error {
+	if tt == nil {
+		return ErrNilReceiver
+	} else if fn == nil {
+		return nil
+	}
+
+	tt.afterEach = append(tt.afterEach, fn)
 
 	return nil
 }
 
+// setupAll runs the BeforeAll hooks and registers the AfterAll hooks.
+//
+// Parameters:
+//   - t: The testing.T instance to use for reporting.
+//
+// Returns:
+//   - bool: True if the hooks succeeded, false otherwise.
+func (tt TestSet[T]) setupAll(t *testing.T) bool {
+	for _, hook := range tt.beforeAll {
+		err := hook()
+		if err != nil {
+			t.Error(NewErrSetup("BeforeAll", "", err))
+			return false
+		}
+	}
+
+	for _, hook := range tt.afterAll {
+		t.Cleanup(func() {
+			err := hook()
+			if err != nil {
+				t.Error(NewErrSetup("AfterAll", "", err))
+			}
+		})
+	}
+
+	return true
+}
+
+// runCase runs the BeforeEach hooks, registers the AfterEach hooks and runs
+// the test at the given index.
+//
+// Parameters:
+//   - t: The testing.T instance of the subtest.
+//   - idx: The index of the test.
+func (tt TestSet[T]) runCase(t *testing.T, idx int) {
+	instance := tt.instances[idx]
+	args := tt.args[idx]
+
+	for _, hook := range tt.beforeEach {
+		err := hook(instance.name, args)
+		if err != nil {
+			t.Error(NewErrSetup("BeforeEach", instance.name, err))
+			return
+		}
+	}
+
+	var timed_out bool
+
+	if len(tt.afterEach) > 0 {
+		t.Cleanup(func() {
+			if timed_out {
+				t.Log("AfterEach hooks skipped: the test is still running")
+			}
+		})
+	}
+
+	for _, hook := range tt.afterEach {
+		t.Cleanup(func() {
+			if timed_out {
+				return
+			}
+
+			err := hook(instance.name, args)
+			if err != nil {
+				t.Error(NewErrSetup("AfterEach", instance.name, err))
+			}
+		})
+	}
+
+	timed_out = !instance.run(t, tt.repanic)
+}
+
 // Run runs all tests in the collection. Does nothing if there are no tests.
 //
 // Parameters:
@@ -80,16 +405,16 @@ func (tt TestSet[T]) Run(t *testing.T) uint {
 		panic("parameter (t) must not be nil")
 	}
 
+	ok := tt.setupAll(t)
+	if !ok {
+		return uint(len(tt.instances))
+	}
+
 	var count uint
 
-	for _, instance := range tt.instances {
+	for i, instance := range tt.instances {
 		fn := func(t *testing.T) {
-			err := instance.fn()
-			if err == nil {
-				return
-			}
-
-			t.Error(err)
+			tt.runCase(t, i)
 		}
 
 		ok := t.Run(instance.name, fn)
@@ -100,3 +425,87 @@ func (tt TestSet[T]) Run(t *testing.T) uint {
 
 	return count
 }
+
+// ParallelOptions are the options of TestSet.RunParallel.
+type ParallelOptions struct {
+	// Group is the name of the subtest that groups the tests. If empty,
+	// "parallel" is used.
+	Group string
+
+	// MaxConcurrency is the maximum number of tests that run at the same
+	// time. If zero or negative, only the -parallel flag of go test limits
+	// the concurrency.
+	MaxConcurrency int
+}
+
+// RunParallel is like Run but the tests run in parallel with each other.
+// They run in a subtest named after opts.Group, which returns once every
+// test is done, so that the number of failed tests is known.
+//
+// Parameters:
+//   - t: The testing.T instance to use for reporting.
+//   - opts: The options.
+//
+// Returns:
+//   - uint: The number of tests that are failed.
+//
+// Panics:
+//   - "parameter (t) must not be nil": If t is nil.
+//
+// Example:
+//
+//	_ = tests.RunParallel(t, test.ParallelOptions{
+//		MaxConcurrency: 8,
+//	})
+func (tt TestSet[T]) RunParallel(t *testing.T, opts ParallelOptions) uint {
+	if len(tt.instances) == 0 {
+		return 0
+	} else if t == nil {
+		panic("parameter (t) must not be nil")
+	}
+
+	group := opts.Group
+	if group == "" {
+		group = "parallel"
+	}
+
+	var sem chan struct{}
+
+	if opts.MaxConcurrency > 0 {
+		sem = make(chan struct{}, opts.MaxConcurrency)
+	}
+
+	ok := tt.setupAll(t)
+	if !ok {
+		return uint(len(tt.instances))
+	}
+
+	var count atomic.Uint64
+
+	_ = t.Run(group, func(t *testing.T) {
+		for i, instance := range tt.instances {
+			fn := func(t *testing.T) {
+				t.Parallel()
+
+				// Registered first so that it runs after the AfterEach hooks,
+				// which may fail the test too.
+				t.Cleanup(func() {
+					if t.Failed() {
+						count.Add(1)
+					}
+				})
+
+				if sem != nil {
+					sem <- struct{}{}
+					defer func() { <-sem }()
+				}
+
+				tt.runCase(t, i)
+			}
+
+			_ = t.Run(instance.name, fn)
+		}
+	})
+
+	return uint(count.Load())
+}
@@ -0,0 +1,577 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunParallel tests the TestSet.RunParallel method.
+func TestRunParallel(t *testing.T) {
+	var running, peak atomic.Int32
+
+	fn := func(args int) TestingFn {
+		fn := func() error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	for i := range 8 {
+		_ = tests.Add(string(rune('a'+i)), i)
+	}
+
+	count := tests.RunParallel(t, ParallelOptions{
+		MaxConcurrency: 2,
+	})
+
+	err := CHECK.Uint("failed tests", 0, count)
+	if err != nil {
+		t.Error(err)
+	}
+
+	n := peak.Load()
+	if n > 2 {
+		t.Errorf("want peak concurrency to be at most 2, got %d", n)
+	}
+}
+
+// TestHooks tests the order in which the hooks of a TestSet run.
+func TestHooks(t *testing.T) {
+	var events []string
+
+	t.Cleanup(func() {
+		want := "before all, before a 1, test 1, after a 1, before b 2, test 2, after b 2, after all"
+		got := strings.Join(events, ", ")
+
+		err := CHECK.String("events", want, got)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	fn := func(args int) TestingFn {
+		fn := func() error {
+			events = append(events, "test "+strconv.Itoa(args))
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.BeforeAll(func() error {
+		events = append(events, "before all")
+		return nil
+	})
+
+	_ = tests.AfterAll(func() error {
+		events = append(events, "after all")
+		return nil
+	})
+
+	_ = tests.BeforeEach(func(name string, args int) error {
+		events = append(events, "before "+name+" "+strconv.Itoa(args))
+		return nil
+	})
+
+	_ = tests.AfterEach(func(name string, args int) error {
+		events = append(events, "after "+name+" "+strconv.Itoa(args))
+		return nil
+	})
+
+	_ = tests.Add("a", 1)
+	_ = tests.Add("b", 2)
+
+	_ = tests.Run(t)
+}
+
+// TestErrSetup tests the message of ErrSetup.
+func TestErrSetup(t *testing.T) {
+	err := NewErrSetup("BeforeEach", "a", errors.New("foo"))
+
+	e := CHECK.ErrorMessage("", "setup: BeforeEach failed for a: foo", err)
+	if e != nil {
+		t.Error(e)
+	}
+
+	err = NewErrSetup("BeforeAll", "", errors.New("foo"))
+
+	e = CHECK.ErrorMessage("", "setup: BeforeAll failed: foo", err)
+	if e != nil {
+		t.Error(e)
+	}
+}
+
+// TestContextTestSet tests that the context of a test carries its timeout.
+func TestContextTestSet(t *testing.T) {
+	type args struct {
+		want_max time.Duration
+	}
+
+	fn := func(args args) ContextTestingFn {
+		fn := func(ctx context.Context) error {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				err := NewErrTest("deadline", "a deadline", "none")
+				return err
+			}
+
+			left := time.Until(deadline)
+			if left > args.want_max {
+				err := NewErrTest("time left", "at most "+args.want_max.String(), left.String())
+				return err
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewContextTestSet(fn)
+
+	_ = tests.AddTimeout("per-case timeout", args{
+		want_max: time.Second,
+	}, time.Second)
+
+	_ = tests.Run(t)
+}
+
+// TestErrTimeout tests the ErrTimeout error.
+func TestErrTimeout(t *testing.T) {
+	err := NewErrTimeout("a", time.Second, "goroutine 1 [running]:")
+
+	e := CHECK.ErrorMessage("", "timeout: a did not finish within 1s", err)
+	if e != nil {
+		t.Error(e)
+	}
+
+	e = CHECK.String("verbose message", "timeout: a did not finish within 1s\n\ngoroutine 1 [running]:", fmt.Sprintf("%+v", err))
+	if e != nil {
+		t.Error(e)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want errors.Is(err, context.DeadlineExceeded) to be true, got false")
+	}
+}
+
+// helperEnv is the environment variable that names the helper test run by
+// runHelper.
+const helperEnv string = "GO_VERIFY_HELPER"
+
+// runHelper runs the given helper test in a subprocess, since its tests fail
+// on purpose, and returns its output.
+//
+// Parameters:
+//   - t: The testing.T instance of the calling test.
+//   - name: The name of the helper test.
+//   - flags: The extra flags of the test binary.
+//
+// Returns:
+//   - string: The combined output of the subprocess.
+func runHelper(t *testing.T, name string, flags ...string) string {
+	t.Helper()
+
+	args := append([]string{"-test.run=^" + name + "$", "-test.v"}, flags...)
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), helperEnv+"="+name)
+
+	out, _ := cmd.CombinedOutput()
+	return string(out)
+}
+
+// skipHelper skips the helper test unless it is run by runHelper.
+//
+// Parameters:
+//   - t: The testing.T instance of the helper test.
+func skipHelper(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv(helperEnv) != t.Name() {
+		t.Skip("only run in a subprocess")
+	}
+}
+
+// checkOutput checks that the output of a helper test contains every wanted
+// string.
+//
+// Parameters:
+//   - t: The testing.T instance of the calling test.
+//   - out: The output of the helper test.
+//   - wants: The wanted strings.
+func checkOutput(t *testing.T, out string, wants ...string) {
+	t.Helper()
+
+	for _, want := range wants {
+		if !strings.Contains(out, want) {
+			t.Errorf("want the output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunParallelFailHelper is run in a subprocess by TestRunParallelFail.
+func TestRunParallelFailHelper(t *testing.T) {
+	skipHelper(t)
+
+	fn := func(args int) TestingFn {
+		fn := func() error {
+			time.Sleep(time.Millisecond)
+
+			switch {
+			case args == 7:
+				panic("boom")
+			case args%3 == 0:
+				err := NewErrTest("n", "not a multiple of 3", strconv.Itoa(args))
+				return err
+			default:
+				return nil
+			}
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	for i := range 10 {
+		_ = tests.Add("case "+strconv.Itoa(i), i)
+	}
+
+	count := tests.RunParallel(t, ParallelOptions{
+		MaxConcurrency: 3,
+	})
+
+	fmt.Printf("failed: %d\n", count)
+}
+
+// TestRunParallelFail tests that RunParallel counts exactly the tests that
+// failed.
+func TestRunParallelFail(t *testing.T) {
+	out := runHelper(t, "TestRunParallelFailHelper")
+
+	// 0, 3, 6 and 9 return an error and 7 panics.
+	checkOutput(t, out,
+		"--- FAIL: TestRunParallelFailHelper/parallel/case_9",
+		"panic in case 7: boom",
+		"--- PASS: TestRunParallelFailHelper/parallel/case_8",
+		"failed: 5\n",
+	)
+}
+
+// TestRunPanicHelper is run in a subprocess by TestRunPanic.
+func TestRunPanicHelper(t *testing.T) {
+	skipHelper(t)
+
+	fn := func(args string) TestingFn {
+		fn := func() error {
+			if args != "" {
+				panic(args)
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.Add("boom", "foo")
+	_ = tests.Add("fine", "")
+
+	count := tests.Run(t)
+	fmt.Printf("failed: %d\n", count)
+}
+
+// TestRunPanic tests that a panicking test is reported and counted as failed
+// without stopping the other tests.
+func TestRunPanic(t *testing.T) {
+	out := runHelper(t, "TestRunPanicHelper")
+
+	checkOutput(t, out,
+		"panic in boom: foo",
+		"tests_test.go",
+		"--- PASS: TestRunPanicHelper/fine",
+		"failed: 1\n",
+	)
+}
+
+// TestHooksFailHelper is run in a subprocess by TestHooksFail.
+func TestHooksFailHelper(t *testing.T) {
+	skipHelper(t)
+
+	var ran, after []string
+
+	fn := func(args string) TestingFn {
+		fn := func() error {
+			ran = append(ran, args)
+
+			switch args {
+			case "fail":
+				err := NewErrTest("result", "ok", "not ok")
+				return err
+			case "panic":
+				panic("boom")
+			default:
+				return nil
+			}
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.BeforeEach(func(name string, args string) error {
+		if name == "skip" {
+			return errors.New("no fixture")
+		}
+
+		return nil
+	})
+
+	_ = tests.AfterEach(func(name string, args string) error {
+		after = append(after, name)
+
+		if name == "teardown" {
+			return errors.New("cannot remove fixture")
+		}
+
+		return nil
+	})
+
+	_ = tests.AfterAll(func() error {
+		fmt.Printf("ran: %s\n", strings.Join(ran, ", "))
+		fmt.Printf("after each: %s\n", strings.Join(after, ", "))
+
+		return nil
+	})
+
+	for _, name := range []string{"ok", "fail", "panic", "skip", "teardown"} {
+		_ = tests.Add(name, name)
+	}
+
+	count := tests.Run(t)
+	fmt.Printf("failed: %d\n", count)
+}
+
+// TestHooksFail tests that the failures of the hooks are reported as
+// ErrSetup errors and that the AfterEach hooks run even if the test failed
+// or panicked.
+func TestHooksFail(t *testing.T) {
+	out := runHelper(t, "TestHooksFailHelper")
+
+	checkOutput(t, out,
+		"setup: BeforeEach failed for skip: no fixture",
+		"setup: AfterEach failed for teardown: cannot remove fixture",
+		"want result to be ok, got not ok",
+		"panic in panic: boom",
+		"ran: ok, fail, panic, teardown\n",
+		"after each: ok, fail, panic, teardown\n",
+		"failed: 4\n",
+	)
+
+	if strings.Contains(out, "setup: AfterEach failed for fail") {
+		t.Errorf("want the failure of the test to be kept apart from the hooks, got:\n%s", out)
+	}
+}
+
+// TestBeforeAllFailHelper is run in a subprocess by TestBeforeAllFail.
+func TestBeforeAllFailHelper(t *testing.T) {
+	skipHelper(t)
+
+	fn := func(args int) TestingFn {
+		fn := func() error {
+			fmt.Println("test ran")
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.BeforeAll(func() error {
+		return errors.New("no database")
+	})
+
+	_ = tests.Add("a", 1)
+	_ = tests.Add("b", 2)
+
+	count := tests.Run(t)
+	fmt.Printf("failed: %d\n", count)
+}
+
+// TestBeforeAllFail tests that the tests do not run and are counted as
+// failed if a BeforeAll hook fails.
+func TestBeforeAllFail(t *testing.T) {
+	out := runHelper(t, "TestBeforeAllFailHelper")
+
+	checkOutput(t, out,
+		"setup: BeforeAll failed: no database",
+		"failed: 2\n",
+	)
+
+	if strings.Contains(out, "test ran") {
+		t.Errorf("want the tests not to run, got:\n%s", out)
+	}
+}
+
+// TestRunParallelTeardownHelper is run in a subprocess by
+// TestRunParallelTeardown.
+func TestRunParallelTeardownHelper(t *testing.T) {
+	skipHelper(t)
+
+	fn := func(args int) TestingFn {
+		fn := func() error {
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.AfterEach(func(name string, args int) error {
+		return errors.New("cannot remove fixture")
+	})
+
+	_ = tests.Add("a", 1)
+	_ = tests.Add("b", 2)
+
+	count := tests.RunParallel(t, ParallelOptions{})
+	fmt.Printf("failed: %d\n", count)
+}
+
+// TestRunParallelTeardown tests that RunParallel counts the tests whose
+// AfterEach hooks failed.
+func TestRunParallelTeardown(t *testing.T) {
+	out := runHelper(t, "TestRunParallelTeardownHelper")
+
+	checkOutput(t, out,
+		"setup: AfterEach failed for a: cannot remove fixture",
+		"failed: 2\n",
+	)
+}
+
+// TestTimeoutHelper is run in a subprocess by TestTimeout.
+func TestTimeoutHelper(t *testing.T) {
+	skipHelper(t)
+
+	fn := func(args string) ContextTestingFn {
+		fn := func(ctx context.Context) error {
+			if args == "hang" {
+				// Ignores the context on purpose.
+				time.Sleep(time.Hour)
+			}
+
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewContextTestSet(fn)
+
+	_ = tests.AfterEach(func(name string, args string) error {
+		fmt.Printf("after each: %s\n", name)
+		return nil
+	})
+
+	_ = tests.AddTimeout("slow", "hang", 50*time.Millisecond)
+	_ = tests.Add("fine", "")
+	_ = tests.Add("stuck", "hang")
+
+	count := tests.Run(t)
+	fmt.Printf("failed: %d\n", count)
+}
+
+// TestTimeout tests that a test that overruns its timeout, or the deadline
+// of go test, is reported with a goroutine dump without stopping the other
+// tests.
+func TestTimeout(t *testing.T) {
+	out := runHelper(t, "TestTimeoutHelper", "-test.timeout=3s")
+
+	checkOutput(t, out,
+		"timeout: slow did not finish within 50ms",
+		"timeout: stuck did not finish within",
+		"goroutine ",
+		"tests_test.go",
+		"--- PASS: TestTimeoutHelper/fine",
+		"after each: fine\n",
+		"AfterEach hooks skipped",
+		"failed: 2\n",
+	)
+
+	if strings.Contains(out, "after each: slow") {
+		t.Errorf("want the AfterEach hooks to be skipped for a timed out test, got:\n%s", out)
+	}
+}
+
+// explode panics with the given value.
+func explode(v string) {
+	panic(v)
+}
+
+// TestRepanicHelper is run in a subprocess by TestRepanic.
+func TestRepanicHelper(t *testing.T) {
+	skipHelper(t)
+
+	fn := func(args string) TestingFn {
+		fn := func() error {
+			explode(args)
+			return nil
+		}
+
+		return fn
+	}
+
+	tests := NewTestSet(fn)
+
+	_ = tests.SetRepanic(true)
+	_ = tests.Add("boom", "foo")
+
+	_ = tests.Run(t)
+	fmt.Println("still running")
+}
+
+// TestRepanic tests that SetRepanic propagates the panic of a test with the
+// stack trace of the function that panicked.
+func TestRepanic(t *testing.T) {
+	out := runHelper(t, "TestRepanicHelper")
+
+	checkOutput(t, out,
+		"panic: foo",
+		"test.explode(",
+	)
+
+	if strings.Contains(out, "still running") {
+		t.Errorf("want the panic to stop the test binary, got:\n%s", out)
+	}
+}
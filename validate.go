@@ -0,0 +1,388 @@
+package assert
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validater is an interface that defines a method that checks the struct's
+// inner state/integrity.
+type Validater interface {
+	// Validate validates the struct. Each implementation of Validater should
+	// describe in the comments the conditions under which the struct is valid.
+	//
+	// Returns:
+	//   - error: An error if the struct is invalid. Nil otherwise.
+	//
+	// NOTES: This method should not modify the struct's state. For that, use
+	// Fixer instead.
+	Validate() error
+}
+
+// validaterType is the reflect.Type of the Validater interface.
+var validaterType reflect.Type = reflect.TypeFor[Validater]()
+
+// Validate asserts whether the struct's inner state is valid. If not, the
+// failure is sent to the handler and, if it requests it, Validate panics with
+// an ErrValidateFailed error, if v is nil, or an ErrValidateFailures error.
+// The handler receives an ErrAssertFail whose inner error is the validation
+// error.
+//
+// Besides v itself, every exported struct field, slice or array element and
+// map value reachable from v that implements Validater is validated as well.
+// An embedded field is not validated on its own when its struct implements
+// Validater, since the Validate method of the struct is either promoted from
+// the field or shadows it. Every failure is collected, with its path, before
+// panicking.
+//
+// Parameters:
+//   - v: The struct to validate.
+//   - name: The name of the struct. If empty, the name "struct" is used.
+//   - allow_nil: Whether to allow the struct to be nil.
+//
+// Example:
+//
+//	type MyStruct struct {
+//		Name string
+//	}
+//
+//	func (ms MyStruct) Validate() error {
+//		if ms.Name == "" {
+//			return errors.New("name cannot be empty")
+//		}
+//
+//		return nil
+//	}
+//
+//	ms := &MyStruct{
+//		Name: "",
+//	}
+//
+//	Validate(ms, "ms", false) // Panics: (Validate Failed) ms = name cannot be empty
+func Validate(v Validater, name string, allow_nil bool) {
+	if !enabled {
+		return
+	}
+
+	var err error
+
+	if isNil(v) {
+		if allow_nil {
+			return
+		}
+
+		err = NewErrValidateFailed(name, nil)
+	} else {
+		err = ValidateAll(v, name)
+		if err == nil {
+			return
+		}
+	}
+
+	fail := newErrAssertFail(err.Error())
+	fail.Inner = err
+
+	h := *handler.Load()
+	raise(h, fail, err)
+}
+
+// ValidateAll is the non-panicking form of Validate. It validates v and
+// everything reachable from it that implements Validater, and returns every
+// failure.
+//
+// Nil pointers, interfaces, slices and maps are skipped.
+//
+// Parameters:
+//   - v: The value to validate.
+//   - name: The name of the value. If empty, the name "struct" is used.
+//
+// Returns:
+//   - error: An *ErrValidateFailures error if at least one validation failed.
+//     Nil otherwise.
+//
+// Example:
+//
+//	type Config struct {
+//		Servers []Server // Server implements Validater
+//	}
+//
+//	err := ValidateAll(cfg, "cfg")
+//	// err: (Validate Failed) cfg.Servers[1] = port must be positive
+func ValidateAll(v any, name string) error {
+	if name == "" {
+		name = "struct"
+	}
+
+	w := &validator{
+		visited: make(map[walked]struct{}),
+	}
+
+	w.walk(name, reflect.ValueOf(v))
+
+	if len(w.failures) == 0 {
+		return nil
+	}
+
+	err := &ErrValidateFailures{
+		Failures: w.failures,
+	}
+
+	return err
+}
+
+// walked is a pointer already walked by a validator.
+type walked struct {
+	// addr is the address of the pointer.
+	addr uintptr
+
+	// typ is the type of the pointer.
+	typ reflect.Type
+}
+
+// validator collects the failures of the Validater values reachable from a
+// root value.
+type validator struct {
+	// failures is the list of failures found so far.
+	failures []*ErrValidateFailed
+
+	// visited is the set of pointers already walked. The type is part of the
+	// key since a struct and its first field share the same address.
+	visited map[walked]struct{}
+}
+
+// check calls the Validate method of v, if any.
+//
+// Parameters:
+//   - path: The path of the value.
+//   - v: The value to check.
+//
+// Returns:
+//   - bool: True if v implements Validater, false otherwise.
+func (w *validator) check(path string, v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+
+	var target reflect.Value
+
+	if v.Type().Implements(validaterType) {
+		target = v
+	} else if v.CanAddr() && v.Addr().Type().Implements(validaterType) {
+		target = v.Addr()
+	} else {
+		return false
+	}
+
+	err := target.Interface().(Validater).Validate()
+	if err == nil {
+		return true
+	}
+
+	w.failures = append(w.failures, &ErrValidateFailed{
+		Name:   path,
+		Reason: err,
+	})
+
+	return true
+}
+
+// walk validates v and everything reachable from it.
+//
+// Parameters:
+//   - path: The path of the value.
+//   - v: The value to walk.
+func (w *validator) walk(path string, v reflect.Value) {
+	if !v.IsValid() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+
+		key := walked{
+			addr: v.Pointer(),
+			typ:  v.Type(),
+		}
+
+		_, ok := w.visited[key]
+		if ok {
+			return
+		}
+
+		w.visited[key] = struct{}{}
+
+		if v.Elem().Kind() == reflect.Struct && v.Type().Implements(validaterType) {
+			// Checked here so that the pointee is not checked a second
+			// time through its method set.
+			w.check(path, v)
+			w.walkFields(path, v.Elem(), true)
+
+			return
+		}
+
+		w.walk(path, v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		w.walk(path, v.Elem())
+	case reflect.Struct:
+		checked := w.check(path, v)
+		w.walkFields(path, v, checked)
+	case reflect.Slice, reflect.Array:
+		w.check(path, v)
+
+		for i := 0; i < v.Len(); i++ {
+			w.walk(path+"["+strconv.Itoa(i)+"]", v.Index(i))
+		}
+	case reflect.Map:
+		w.check(path, v)
+
+		keys := v.MapKeys()
+
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(formatValue(a), formatValue(b))
+		})
+
+		for _, key := range keys {
+			w.walk(path+"["+formatValue(key)+"]", v.MapIndex(key))
+		}
+	default:
+		w.check(path, v)
+	}
+}
+
+// walkFields walks the exported fields of a struct.
+//
+// Parameters:
+//   - path: The path of the struct.
+//   - v: The struct value.
+//   - checked: Whether the struct was validated. If so, its embedded fields
+//     are not validated again, but their own fields are still walked.
+func (w *validator) walkFields(path string, v reflect.Value, checked bool) {
+	typ := v.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := path + "." + field.Name
+		fv := v.Field(i)
+
+		if !checked || !field.Anonymous {
+			w.walk(name, fv)
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			w.walkFields(name, fv, true)
+		case reflect.Pointer:
+			if fv.IsNil() || fv.Elem().Kind() != reflect.Struct {
+				continue
+			}
+
+			key := walked{
+				addr: fv.Pointer(),
+				typ:  fv.Type(),
+			}
+
+			_, ok := w.visited[key]
+			if ok {
+				continue
+			}
+
+			w.visited[key] = struct{}{}
+			w.walkFields(name, fv.Elem(), true)
+		}
+	}
+}
+
+// isNil checks whether v is nil, including typed nil pointers, maps, slices,
+// channels and functions stored in an interface.
+//
+// Parameters:
+//   - v: The value to check.
+//
+// Returns:
+//   - bool: True if v is nil, false otherwise.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+// Fixer is an interface that defines a method that validates and tries to bring the
+// struct into the closest valid state.
+type Fixer interface {
+	// Fix fixes the struct. Each implementation of Fixer should describe in the
+	// comments the conditions under which the struct is valid.
+	//
+	// Returns:
+	//   - error: An error if the struct could not be fixed. Nil otherwise.
+	Fix() error
+}
+
+// Fix fixes the struct to the closest valid state. Panics with an ErrFixFailed
+// error if the struct could not be fixed.
+//
+// Unlike the assertions, Fix is never compiled away since it modifies the
+// struct.
+//
+// Parameters:
+//   - v: The struct to fix.
+//   - name: The name of the struct. If empty, the name "struct" is used.
+//   - allow_nil: Whether to allow the struct to be nil.
+//
+// Example:
+//
+//	type MyStruct struct {
+//		Name string
+//	}
+//
+//	func (ms *MyStruct) Fix() error {
+//		if ms == nil {
+//			return nil
+//		}
+//
+//		if ms.Name == "" {
+//			return errors.New("name cannot be empty")
+//		}
+//
+//		return nil
+//	}
+//
+//	ms := &MyStruct{
+//		Name: "",
+//	}
+//
+//	Fix(ms, "ms", false) // Panics: (Fix Failed) ms = name cannot be empty
+func Fix(v Fixer, name string, allow_nil bool) {
+	if v == nil && !allow_nil {
+		panic(NewErrFixFailed(name, nil))
+	} else if v == nil {
+		return
+	}
+
+	err := v.Fix()
+	if err != nil {
+		panic(NewErrFixFailed(name, err))
+	}
+}
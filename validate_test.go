@@ -0,0 +1,348 @@
+//go:build !verify_noassert
+
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	test "github.com/PlayerR9/go-verify/test"
+)
+
+// MockStruct is a mock struct.
+type MockStruct struct {
+	// name is the name of the mock struct.
+	name string
+}
+
+// Validate implements Validater.
+func (ms MockStruct) Validate() error {
+	if ms.name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	return nil
+}
+
+// Fix implements Fixer.
+func (ms *MockStruct) Fix() error {
+	if ms == nil {
+		return nil
+	}
+
+	if ms.name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	ms.name = "foo"
+
+	return nil
+}
+
+// MockContainer is a mock struct that contains Validater values.
+type MockContainer struct {
+	// Main is a Validater field.
+	Main MockStruct
+
+	// List is a slice of Validater values.
+	List []*MockStruct
+
+	// Index is a map of Validater values.
+	Index map[string]MockStruct
+
+	// hidden is an unexported field that is not validated.
+	hidden MockStruct
+}
+
+// TestValidate tests the Validate function.
+func TestValidate(t *testing.T) {
+	type args struct {
+		v         Validater
+		name      string
+		allow_nil bool
+		want      string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(func() {
+				Validate(args.v, args.name, args.allow_nil)
+			})
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("valid", args{
+		v: &MockStruct{
+			name: "foo",
+		},
+		name:      "ms",
+		allow_nil: false,
+		want:      "",
+	})
+
+	_ = tests.Add("nil without allow_nil", args{
+		v:         nil,
+		name:      "",
+		allow_nil: false,
+		want:      "(Validate Failed) struct = nil",
+	})
+
+	_ = tests.Add("nil with allow_nil", args{
+		v:         nil,
+		name:      "",
+		allow_nil: true,
+		want:      "",
+	})
+
+	_ = tests.Add("invalid", args{
+		v: &MockStruct{
+			name: "",
+		},
+		name:      "ms",
+		allow_nil: false,
+		want:      "(Validate Failed) ms = name cannot be empty",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestValidateAll tests that ValidateAll collects every failure with its
+// path.
+func TestValidateAll(t *testing.T) {
+	c := &MockContainer{
+		Main: MockStruct{name: "foo"},
+		List: []*MockStruct{{name: "foo"}, {name: ""}, nil},
+		Index: map[string]MockStruct{
+			"b": {name: ""},
+			"a": {name: "foo"},
+		},
+		hidden: MockStruct{name: ""},
+	}
+
+	err := ValidateAll(c, "c")
+
+	var failures *ErrValidateFailures
+
+	ok := errors.As(err, &failures)
+	if !ok {
+		t.Fatalf("want *ErrValidateFailures, got %T", err)
+	}
+
+	e := test.CHECK.Int("number of failures", 2, len(failures.Failures))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	e = test.CHECK.String("first path", "c.List[1]", failures.Failures[0].Name)
+	if e != nil {
+		t.Error(e)
+	}
+
+	e = test.CHECK.String("second path", `c.Index["b"]`, failures.Failures[1].Name)
+	if e != nil {
+		t.Error(e)
+	}
+
+	var failure *ErrValidateFailed
+
+	ok = errors.As(err, &failure)
+	if !ok {
+		t.Errorf("want errors.As to find an *ErrValidateFailed")
+	}
+
+	err = ValidateAll(&MockContainer{Main: MockStruct{name: "foo"}}, "c")
+
+	e = test.CHECK.ErrorMessage("", "", err)
+	if e != nil {
+		t.Error(e)
+	}
+}
+
+// TestFix tests the Fix function.
+func TestFix(t *testing.T) {
+	type args struct {
+		v         Fixer
+		name      string
+		allow_nil bool
+		want      string
+	}
+
+	fn := func(args args) test.TestingFn {
+		fn := func() error {
+			caught := test.Try(func() {
+				Fix(args.v, args.name, args.allow_nil)
+			})
+
+			err := test.CHECK.ErrorMessage("", args.want, caught)
+			return err
+		}
+
+		return fn
+	}
+
+	tests := test.NewTestSet(fn)
+
+	_ = tests.Add("valid", args{
+		v: &MockStruct{
+			name: "foo",
+		},
+		name:      "ms",
+		allow_nil: false,
+		want:      "",
+	})
+
+	_ = tests.Add("nil without allow_nil", args{
+		v:         nil,
+		name:      "",
+		allow_nil: false,
+		want:      "(Fix Failed) struct = nil",
+	})
+
+	_ = tests.Add("nil with allow_nil", args{
+		v:         nil,
+		name:      "",
+		allow_nil: true,
+		want:      "",
+	})
+
+	_ = tests.Add("invalid", args{
+		v: &MockStruct{
+			name: "",
+		},
+		name:      "ms",
+		allow_nil: false,
+		want:      "(Fix Failed) ms = name cannot be empty",
+	})
+
+	_ = tests.Run(t)
+}
+
+// TestValidateMode tests that Validate follows the mode and the handler.
+func TestValidateMode(t *testing.T) {
+	prev := SetMode(ModeCount)
+	defer SetMode(prev)
+
+	before := FailCount()
+
+	caught := test.Try(func() {
+		Validate(&MockStruct{name: ""}, "ms", false)
+		Validate(nil, "ms", false)
+	})
+
+	err := test.CHECK.ErrorMessage("", "", caught)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = test.CHECK.Uint("fail count", 2, uint(FailCount()-before))
+	if err != nil {
+		t.Error(err)
+	}
+
+	var got *ErrAssertFail
+
+	prev_h := SetHandler(func(err *ErrAssertFail) bool {
+		got = err
+		return false
+	})
+	defer SetHandler(prev_h)
+
+	Validate(&MockStruct{name: ""}, "ms", false)
+
+	var failure *ErrValidateFailed
+
+	ok := errors.As(got, &failure)
+	if !ok {
+		t.Fatalf("want the handler to receive an *ErrValidateFailed, got %v", got)
+	}
+
+	err = test.CHECK.String("name", "ms", failure.Name)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// MockOuter is a mock struct whose first field is a Validater.
+type MockOuter struct {
+	// Inner is the first field, which shares the address of the struct.
+	Inner MockStruct
+}
+
+// Validate implements Validater.
+func (mo *MockOuter) Validate() error {
+	return errors.New("outer is invalid")
+}
+
+// MockRefs is a mock struct that refers to a struct and to its first field.
+type MockRefs struct {
+	// Outer is a pointer to a struct.
+	Outer *MockOuter
+
+	// Inner is a pointer to the first field of Outer.
+	Inner *MockStruct
+}
+
+// TestValidateAllSameAddress tests that a struct and its first field are
+// both validated although they share the same address.
+func TestValidateAllSameAddress(t *testing.T) {
+	outer := &MockOuter{}
+
+	err := ValidateAll(MockRefs{Outer: outer, Inner: &outer.Inner}, "refs")
+
+	var failures *ErrValidateFailures
+
+	ok := errors.As(err, &failures)
+	if !ok {
+		t.Fatalf("want *ErrValidateFailures, got %T", err)
+	}
+
+	names := make([]string, 0, len(failures.Failures))
+	for _, failure := range failures.Failures {
+		names = append(names, failure.Name)
+	}
+
+	e := test.CHECK.String("paths", "refs.Outer refs.Outer.Inner refs.Inner", strings.Join(names, " "))
+	if e != nil {
+		t.Error(e)
+	}
+}
+
+// MockEmbedded is a mock struct whose Validate method is promoted from an
+// embedded field.
+type MockEmbedded struct {
+	MockStruct
+
+	// Main is a Validater field.
+	Main MockStruct
+}
+
+// TestValidateAllEmbedded tests that an embedded Validater is validated only
+// once, through the struct that embeds it.
+func TestValidateAllEmbedded(t *testing.T) {
+	err := ValidateAll(MockEmbedded{}, "o")
+
+	var failures *ErrValidateFailures
+
+	ok := errors.As(err, &failures)
+	if !ok {
+		t.Fatalf("want *ErrValidateFailures, got %T", err)
+	}
+
+	names := make([]string, 0, len(failures.Failures))
+	for _, failure := range failures.Failures {
+		names = append(names, failure.Name)
+	}
+
+	e := test.CHECK.String("paths", "o o.Main", strings.Join(names, " "))
+	if e != nil {
+		t.Error(e)
+	}
+}